@@ -10,6 +10,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/embeddedgo/kendryte/hal/rtc/rtc0"
 )
 
 const dateUsage = `
@@ -36,6 +38,15 @@ func parse(s, sep string) (v [3]int, err error) {
 }
 
 
+// The RTC keeps the time across resets so use it to set the system clock if
+// it seems to be set.
+func init() {
+	if t := rtc0.Driver().Time(); t.Year() >= 2020 {
+		time.Set(time.Now(), t)
+		prompt = "> "
+	}
+}
+
 func date(args []string) {
 	now := time.Now()
 	switch len(args) {
@@ -68,6 +79,7 @@ func date(args []string) {
 			hms[0], hms[1], hms[2], 0, time.Local,
 		)
 		time.Set(now, t)
+		rtc0.Driver().SetTime(t)
 		prompt = "> "
 	case 1:
 		fmt.Println(now.Format(timeLayout))
@@ -0,0 +1,39 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package aes0 provides a ready to use driver for the AES accelerator.
+package aes0
+
+import (
+	"github.com/embeddedgo/kendryte/hal/aes"
+	"github.com/embeddedgo/kendryte/hal/dma"
+	"github.com/embeddedgo/kendryte/hal/dma/dmac0"
+)
+
+var driver *aes.Driver
+
+// Driver returns a ready to use driver for the AES accelerator.
+func Driver() *aes.Driver {
+	if driver == nil {
+		p := aes.AES(0)
+		p.EnableClock()
+		driver = aes.NewDriver(p)
+	}
+	return driver
+}
+
+// EnableDMA allocates a DMAC channel for reading the output of the AES
+// accelerator. It returns false if there is no free DMAC channel.
+func EnableDMA() bool {
+	d := Driver()
+	if d.DMA() != nil {
+		return true
+	}
+	dd := dmac0.AllocFor(dma.AES)
+	if dd == nil {
+		return false
+	}
+	d.SetDMA(dd)
+	return true
+}
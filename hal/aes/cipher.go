@@ -0,0 +1,227 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package aes
+
+import (
+	goaes "crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+	"strconv"
+)
+
+// BlockSize is the AES block size in bytes.
+const BlockSize = 16
+
+type KeySizeError int
+
+func (k KeySizeError) Error() string {
+	return "aes: invalid key size " + strconv.Itoa(int(k))
+}
+
+// Block implements the crypto/cipher.Block interface using the AES
+// accelerator. The methods of Block and of the BlockMode and AEAD values
+// derived from it panic if the DMA transfer fails.
+type Block struct {
+	d   *Driver
+	key []byte
+}
+
+// NewCipher returns a new cipher.Block that uses d. The key must be 16, 24 or
+// 32 bytes long to select AES-128, AES-192 or AES-256.
+func NewCipher(d *Driver, key []byte) (*Block, error) {
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, KeySizeError(len(key))
+	}
+	return &Block{d: d, key: append([]byte(nil), key...)}, nil
+}
+
+func (b *Block) BlockSize() int {
+	return BlockSize
+}
+
+func (b *Block) crypt(m Mode, decrypt bool, iv, aad, dst, src []byte) [16]byte {
+	tag, err := b.d.crypt(m, decrypt, b.key, iv, aad, dst, src)
+	if err != nil {
+		panic("aes: " + err.Error())
+	}
+	return tag
+}
+
+func (b *Block) Encrypt(dst, src []byte) {
+	if len(src) < BlockSize || len(dst) < BlockSize {
+		panic("aes: input not full block")
+	}
+	b.crypt(ECB, false, nil, nil, dst[:BlockSize], src[:BlockSize])
+}
+
+func (b *Block) Decrypt(dst, src []byte) {
+	if len(src) < BlockSize || len(dst) < BlockSize {
+		panic("aes: input not full block")
+	}
+	b.crypt(ECB, true, nil, nil, dst[:BlockSize], src[:BlockSize])
+}
+
+// CryptECB encrypts or decrypts a sequence of blocks in the ECB mode in one
+// hardware operation.
+func (b *Block) CryptECB(dst, src []byte, decrypt bool) {
+	if len(src)%BlockSize != 0 {
+		panic("aes: input not full blocks")
+	}
+	if len(dst) < len(src) {
+		panic("aes: output smaller than input")
+	}
+	if len(src) != 0 {
+		b.crypt(ECB, decrypt, nil, nil, dst, src)
+	}
+}
+
+type cbc struct {
+	b       *Block
+	iv      [BlockSize]byte
+	decrypt bool
+}
+
+// NewCBCEncrypter returns a cipher.BlockMode that encrypts in the hardware
+// CBC mode. It is used by cipher.NewCBCEncrypter.
+func (b *Block) NewCBCEncrypter(iv []byte) cipher.BlockMode {
+	return b.newCBC(iv, false)
+}
+
+// NewCBCDecrypter returns a cipher.BlockMode that decrypts in the hardware
+// CBC mode. It is used by cipher.NewCBCDecrypter.
+func (b *Block) NewCBCDecrypter(iv []byte) cipher.BlockMode {
+	return b.newCBC(iv, true)
+}
+
+func (b *Block) newCBC(iv []byte, decrypt bool) *cbc {
+	if len(iv) != BlockSize {
+		panic("aes: IV length must equal block size")
+	}
+	x := &cbc{b: b, decrypt: decrypt}
+	copy(x.iv[:], iv)
+	return x
+}
+
+func (x *cbc) BlockSize() int {
+	return BlockSize
+}
+
+func (x *cbc) CryptBlocks(dst, src []byte) {
+	n := len(src)
+	if n%BlockSize != 0 {
+		panic("aes: input not full blocks")
+	}
+	if len(dst) < n {
+		panic("aes: output smaller than input")
+	}
+	if n == 0 {
+		return
+	}
+	var next [BlockSize]byte
+	if x.decrypt {
+		copy(next[:], src[n-BlockSize:])
+	}
+	x.b.crypt(CBC, x.decrypt, x.iv[:], nil, dst, src)
+	if !x.decrypt {
+		copy(next[:], dst[n-BlockSize:n])
+	}
+	x.iv = next
+}
+
+const (
+	gcmNonceSize  = 12
+	gcmTagSize    = 16
+	gcmMinTagSize = 12
+)
+
+var errOpen = errors.New("aes: message authentication failed")
+
+type gcm struct {
+	b       *Block
+	tagSize int
+	sw      cipher.AEAD
+}
+
+// NewGCM returns a cipher.AEAD that works in the hardware GCM mode. It is
+// used by cipher.NewGCM and cipher.NewGCMWithTagSize. The engine supports
+// only the standard 12-byte nonce and requires non-empty additional data and
+// plaintext. The empty ones are handled by the crypto/aes software
+// implementation.
+func (b *Block) NewGCM(nonceSize, tagSize int) (cipher.AEAD, error) {
+	if nonceSize != gcmNonceSize {
+		return nil, errors.New("aes: hardware GCM supports only 12-byte nonce")
+	}
+	if tagSize < gcmMinTagSize || tagSize > gcmTagSize {
+		return nil, errors.New("aes: incorrect GCM tag size")
+	}
+	blk, err := goaes.NewCipher(b.key)
+	if err != nil {
+		return nil, err
+	}
+	sw, err := cipher.NewGCMWithTagSize(blk, tagSize)
+	if err != nil {
+		return nil, err
+	}
+	return &gcm{b: b, tagSize: tagSize, sw: sw}, nil
+}
+
+func (g *gcm) NonceSize() int {
+	return gcmNonceSize
+}
+
+func (g *gcm) Overhead() int {
+	return g.tagSize
+}
+
+// sliceForAppend extends in by n bytes. It returns the whole slice and the
+// extension.
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
+
+func (g *gcm) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != gcmNonceSize {
+		panic("aes: incorrect nonce length given to GCM")
+	}
+	if len(plaintext) == 0 || len(additionalData) == 0 {
+		return g.sw.Seal(dst, nonce, plaintext, additionalData)
+	}
+	n := len(plaintext)
+	ret, out := sliceForAppend(dst, n+g.tagSize)
+	tag := g.b.crypt(GCM, false, nonce, additionalData, out[:n], plaintext)
+	copy(out[n:], tag[:g.tagSize])
+	return ret
+}
+
+func (g *gcm) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != gcmNonceSize {
+		panic("aes: incorrect nonce length given to GCM")
+	}
+	if len(ciphertext) < g.tagSize {
+		return nil, errOpen
+	}
+	n := len(ciphertext) - g.tagSize
+	if n == 0 || len(additionalData) == 0 {
+		return g.sw.Open(dst, nonce, ciphertext, additionalData)
+	}
+	expected := ciphertext[n:]
+	ret, out := sliceForAppend(dst, n)
+	tag := g.b.crypt(GCM, true, nonce, additionalData, out, ciphertext[:n])
+	if subtle.ConstantTimeCompare(tag[:g.tagSize], expected) != 1 {
+		clear(out)
+		return nil, errOpen
+	}
+	return ret, nil
+}
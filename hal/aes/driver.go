@@ -0,0 +1,122 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package aes
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/dma"
+)
+
+// Driver is a driver for the AES accelerator. It serializes the operations
+// started by concurrent goroutines. The input data is always written by CPU.
+// The output can be read by DMAC if the driver has a DMA channel (see SetDMA).
+type Driver struct {
+	p  *Periph
+	mx sync.Mutex
+	dd *dma.Driver
+}
+
+// NewDriver returns a new driver for p.
+func NewDriver(p *Periph) *Driver {
+	return &Driver{p: p}
+}
+
+func (d *Driver) Periph() *Periph {
+	return d.p
+}
+
+// SetDMA sets the DMA channel used to read the output of the operations on
+// the buffers at least DMAMinLen bytes long. The channel must be connected to
+// the AES request line, e.g. d.SetDMA(dmac0.AllocFor(dma.AES)). The nil dd
+// disables DMA.
+func (d *Driver) SetDMA(dd *dma.Driver) {
+	d.mx.Lock()
+	d.dd = dd
+	d.mx.Unlock()
+}
+
+// DMA returns the DMA channel set by SetDMA.
+func (d *Driver) DMA() *dma.Driver {
+	return d.dd
+}
+
+// DMAMinLen is the minimum length of the 4-byte aligned buffer that is read
+// using DMA.
+const DMAMinLen = 256
+
+// fifoLen is the size of the engine input/output FIFO in bytes.
+const fifoLen = 80
+
+const dmaCtl = dma.SrcW32 | dma.DstW32 | dma.SrcB1 | dma.DstB1 | dma.SrcNoInc
+
+func loadWord(b []byte) uint32 {
+	if len(b) >= 4 {
+		return le32(b)
+	}
+	var w [4]byte
+	copy(w[:], b)
+	return le32(w[:])
+}
+
+func storeWord(b []byte, w uint32) {
+	if len(b) >= 4 {
+		putLE32(b, w)
+		return
+	}
+	var t [4]byte
+	putLE32(t[:], w)
+	copy(b, t[:])
+}
+
+// crypt performs one operation in the mode m. It encrypts or decrypts src to
+// dst (dst and src may overlap entirely or not at all). In the GCM mode it
+// returns the authentication tag. The length of src must be a multiple of 16
+// in the ECB and CBC modes. The GCM mode requires non-empty aad and src.
+func (d *Driver) crypt(m Mode, decrypt bool, key, iv, aad, dst, src []byte) (tag [16]byte, err error) {
+	n := len(src)
+	dst = dst[:n]
+	d.mx.Lock()
+	defer d.mx.Unlock()
+	p := d.p
+	p.SetKey(key)
+	if iv != nil {
+		p.SetIV(iv)
+	}
+	p.Setup(m, decrypt, len(aad), n)
+	if m == GCM {
+		for i := 0; i < len(aad); i += 4 {
+			p.WriteAAD(loadWord(aad[i:]))
+		}
+	}
+	if dd := d.dd; dd != nil && n >= DMAMinLen && n&3 == 0 &&
+		uintptr(unsafe.Pointer(&dst[0]))&3 == 0 {
+		p.SetDMA(true)
+		dd.Start(unsafe.Pointer(&dst[0]), unsafe.Pointer(&p.outData), n/4, dmaCtl, dma.PTM)
+		for i := 0; i < n; i += 4 {
+			p.WriteText(le32(src[i:]))
+		}
+		err = dd.Wait()
+		p.SetDMA(false)
+	} else {
+		for k := 0; k < n; k += fifoLen {
+			end := k + fifoLen
+			if end > n {
+				end = n
+			}
+			for i := k; i < end; i += 4 {
+				p.WriteText(loadWord(src[i:end]))
+			}
+			for i := k; i < end; i += 4 {
+				storeWord(dst[i:end], p.ReadOut())
+			}
+		}
+	}
+	if m == GCM && err == nil {
+		tag = p.Tag()
+	}
+	return
+}
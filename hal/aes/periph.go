@@ -0,0 +1,236 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package aes provides interface to the AES accelerator.
+//
+// The K210 AES engine supports 128, 192 and 256-bit keys in ECB, CBC and GCM
+// modes. The Block type implements the crypto/cipher.Block interface and is
+// recognized by the cipher.NewCBCEncrypter, cipher.NewCBCDecrypter and
+// cipher.NewGCM functions which then use the hardware CBC and GCM modes.
+package aes
+
+import (
+	"embedded/mmio"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/bus"
+	"github.com/embeddedgo/kendryte/p/mmap"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// Periph represents the AES accelerator.
+type Periph struct {
+	key      [4]mmio.U32
+	decrypt  mmio.U32
+	modeCtl  mmio.U32
+	iv       [4]mmio.U32
+	endian   mmio.U32
+	finish   mmio.U32
+	dmaSel   mmio.U32
+	aadNum   mmio.U32
+	_        uint32
+	pcNum    mmio.U32
+	textData mmio.U32
+	aadData  mmio.U32
+	tagChk   mmio.U32
+	inFlag   mmio.U32
+	inTag    [4]mmio.U32
+	outData  mmio.U32
+	en       mmio.U32
+	outFlag  mmio.U32
+	tagFlag  mmio.U32
+	tagClear mmio.U32
+	outTag   [4]mmio.U32
+	keyExt   [4]mmio.U32
+}
+
+func AES(n int) *Periph {
+	if n != 0 {
+		panic("aes: bad number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.AES_BASE))
+}
+
+func (p *Periph) Bus() bus.Bus {
+	return bus.APB1
+}
+
+func (p *Periph) EnableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_CENT.Lock()
+	if mx.APB1_CLK_EN == 0 {
+		sc.APB1_CLK_EN().Set()
+	}
+	mx.APB1_CLK_EN++
+	mx.CLK_EN_CENT.Unlock()
+
+	mx.CLK_EN_PERI.Lock()
+	sc.AES_CLK_EN().Set()
+	mx.CLK_EN_PERI.Unlock()
+}
+
+func (p *Periph) DisableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_PERI.Lock()
+	sc.AES_CLK_EN().Clear()
+	mx.CLK_EN_PERI.Unlock()
+
+	mx.CLK_EN_CENT.Lock()
+	mx.APB1_CLK_EN--
+	if mx.APB1_CLK_EN == 0 {
+		sc.APB1_CLK_EN().Clear()
+	}
+	mx.CLK_EN_CENT.Unlock()
+}
+
+func (p *Periph) Reset() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.PERI_RESET.Lock()
+	sc.AES_RESET().Set()
+	mx.PERI_RESET.Unlock()
+
+	time.Sleep(10 * time.Microsecond)
+
+	mx.PERI_RESET.Lock()
+	sc.AES_RESET().Clear()
+	mx.PERI_RESET.Unlock()
+}
+
+// The byte order of the key, IV, data and tag registers follows the Kendryte
+// SDK: the multi-word values are stored starting from their last word and
+// every word is loaded in little-endian order.
+
+func le32(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
+}
+
+func putLE32(b []byte, w uint32) {
+	b[0] = byte(w)
+	b[1] = byte(w >> 8)
+	b[2] = byte(w >> 16)
+	b[3] = byte(w >> 24)
+}
+
+// SetKey sets the 16, 24 or 32 byte long key.
+func (p *Periph) SetKey(key []byte) {
+	n := len(key)
+	for i := 0; i < n/4; i++ {
+		w := le32(key[n-4*i-4:])
+		if i < 4 {
+			p.key[i].Store(w)
+		} else {
+			p.keyExt[i-4].Store(w)
+		}
+	}
+	p.modeCtl.StoreBits(3<<3, uint32(n/8-2)<<3)
+}
+
+// SetIV sets the 16 byte long initialization vector (CBC) or the 12 byte long
+// nonce (GCM).
+func (p *Periph) SetIV(iv []byte) {
+	n := len(iv)
+	for i := 0; i < n/4; i++ {
+		p.iv[i].Store(le32(iv[n-4*i-4:]))
+	}
+}
+
+// Mode represents the cipher mode.
+type Mode uint8
+
+const (
+	ECB Mode = 0
+	CBC Mode = 1
+	GCM Mode = 2
+)
+
+// Setup prepares the engine for the new operation: sets the cipher mode, the
+// direction, the number of bytes of additional authenticated data (GCM only)
+// and the number of bytes of text (a multiple of 16 for ECB and CBC). Setup
+// must be called after SetKey and SetIV.
+func (p *Periph) Setup(m Mode, decrypt bool, aadLen, textLen int) {
+	p.endian.SetBits(1)
+	p.modeCtl.StoreBits(7, uint32(m))
+	if decrypt {
+		p.decrypt.Store(1)
+	} else {
+		p.decrypt.Store(0)
+	}
+	p.aadNum.Store(uint32(aadLen - 1))
+	p.pcNum.Store(uint32(textLen - 1))
+	p.en.SetBits(1)
+}
+
+// SetDMA selects the DMA request line as the data output handshake.
+func (p *Periph) SetDMA(en bool) {
+	if en {
+		p.dmaSel.Store(1)
+	} else {
+		p.dmaSel.Store(0)
+	}
+}
+
+// InReady reports whether the engine can accept the next input word.
+func (p *Periph) InReady() bool {
+	return p.inFlag.Load()&1 != 0
+}
+
+// OutReady reports whether the next output word is available.
+func (p *Periph) OutReady() bool {
+	return p.outFlag.Load()&1 != 0
+}
+
+// WriteAAD waits for InReady and writes the next word of additional
+// authenticated data.
+func (p *Periph) WriteAAD(w uint32) {
+	for !p.InReady() {
+	}
+	p.aadData.Store(w)
+}
+
+// WriteText waits for InReady and writes the next word of plaintext
+// (encryption) or ciphertext (decryption).
+func (p *Periph) WriteText(w uint32) {
+	for !p.InReady() {
+	}
+	p.textData.Store(w)
+}
+
+// ReadOut waits for OutReady and reads the next output word.
+func (p *Periph) ReadOut() uint32 {
+	for !p.OutReady() {
+	}
+	return p.outData.Load()
+}
+
+// Tag returns the GCM authentication tag. It must be called after the last
+// output word was read. Tag also passes the tag back to the engine to finish
+// the GCM operation (see SetTag).
+func (p *Periph) Tag() (tag [16]byte) {
+	for i := 0; i < 4; i++ {
+		w := p.outTag[3-i].Load()
+		tag[i*4] = byte(w >> 24)
+		tag[i*4+1] = byte(w >> 16)
+		tag[i*4+2] = byte(w >> 8)
+		tag[i*4+3] = byte(w)
+	}
+	p.SetTag(&tag)
+	return
+}
+
+// SetTag writes the expected GCM tag to the engine.
+func (p *Periph) SetTag(tag *[16]byte) {
+	for p.tagFlag.Load()&1 == 0 {
+	}
+	for i := 0; i < 4; i++ {
+		p.inTag[i].Store(le32(tag[12-4*i:]))
+	}
+}
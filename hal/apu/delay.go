@@ -0,0 +1,59 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package apu
+
+import "math"
+
+// SoundSpeed is the speed of sound in air used by CircleDelays [m/s].
+const SoundSpeed = 340
+
+// CircleDelays calculates the delays of the sound channels for all NumDir
+// directions for the circular microphone array. The array consists of n
+// microphones evenly spaced on the circle of radius r [m] (sound channels 0
+// to n-1, the channel 0 points to the direction 0, the following channels
+// are placed counterclockwise) and optionally one central microphone (sound
+// channel n). The sampleRate is the I2S0 sample rate divided by the direction
+// path down-sizing ratio.
+func CircleDelays(r float64, n int, center bool, sampleRate int) (delays [NumDir][NumCh]uint8) {
+	nch := n
+	if center {
+		nch++
+	}
+	if n < 1 || nch > NumCh {
+		panic("apu: bad number of microphones")
+	}
+	tick := float64(SoundSpeed) / float64(sampleRate) // distance per sample
+	var dl [NumCh]float64
+	for dir := range delays {
+		for i := 0; i < n; i++ {
+			a := 2 * math.Pi * (float64(i)/float64(n) - float64(dir)/NumDir)
+			dl[i] = r * (1 - math.Cos(a)) / tick
+		}
+		if center {
+			dl[n] = r / tick
+		}
+		min := math.Inf(1) // in samples
+		for _, d := range dl[:nch] {
+			min = math.Min(min, d)
+		}
+		for i := 0; i < nch; i++ {
+			d := math.Round(dl[i] - min)
+			if d > 63 {
+				d = 63
+			}
+			delays[dir][i] = uint8(d)
+		}
+	}
+	return
+}
+
+// SetCircleDelays calculates the delays using CircleDelays and sets them in
+// the peripheral.
+func (d *Driver) SetCircleDelays(r float64, n int, center bool, sampleRate int) {
+	delays := CircleDelays(r, n, center, sampleRate)
+	for dir := range delays {
+		d.p.SetDelays(dir, &delays[dir])
+	}
+}
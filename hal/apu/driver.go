@@ -0,0 +1,171 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package apu
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/dma"
+)
+
+type DriverError uint8
+
+const (
+	// ErrNoDMA is returned if the driver has no DMA channel for the requested
+	// data (see SetDMA).
+	ErrNoDMA DriverError = iota + 1
+)
+
+// Error implements error interface.
+func (e DriverError) Error() string {
+	switch e {
+	case ErrNoDMA:
+		return "apu: no DMA"
+	}
+	return ""
+}
+
+// DirLen is the number of samples per direction in one block of the
+// direction search data.
+const DirLen = 512
+
+// DirData represents one block of the direction search data: DirLen
+// beamformed samples for every direction.
+type DirData [NumDir][DirLen]int16
+
+// VoiceLen is the number of samples in one block of the voice stream.
+const VoiceLen = 512
+
+// Driver is a DMA based driver for the APU. It supports one goroutine at a
+// time for every kind of data (the direction search data and the voice stream
+// can be read concurrently).
+//
+// The DMAC channels must be connected to the APU request lines, e.g.:
+//
+//	d := apu.NewDriver(apu.APU())
+//	d.SetDMA(dmac0.AllocFor(dma.I2S0_BF_DIR), dmac0.AllocFor(dma.I2S0_BF_VOICE))
+type Driver struct {
+	p    *Periph
+	dird *dma.Driver
+	vocd *dma.Driver
+}
+
+// NewDriver returns a new driver for p.
+func NewDriver(p *Periph) *Driver {
+	return &Driver{p: p}
+}
+
+func (d *Driver) Periph() *Periph {
+	return d.p
+}
+
+// SetDMA sets the DMA drivers used to read the direction search data (dird)
+// and the voice stream (vocd). Any of them can be nil if not used.
+func (d *Driver) SetDMA(dird, vocd *dma.Driver) {
+	d.dird, d.vocd = dird, vocd
+}
+
+// SetTimeout sets the timeout used by ReadDir and ReadVoice.
+func (d *Driver) SetTimeout(timeout time.Duration) {
+	if d.dird != nil {
+		d.dird.SetTimeout(timeout)
+	}
+	if d.vocd != nil {
+		d.vocd.SetTimeout(timeout)
+	}
+}
+
+// Setup configures the APU to process the sound channels selected by mask
+// (see Periph.SetChannels) with the unity gain, no down-sizing and no sample
+// shift. The APU interrupts are disabled. The FIR filters and the direction
+// delays must be set separately (see Periph.SetFIR, Periph.SetDelays,
+// SetCircleDelays).
+func (d *Driver) Setup(mask uint8) {
+	p := d.p
+	p.DisableDirSearch()
+	p.DisableVoice()
+	p.SetIRQ(0)
+	p.SetChannels(mask)
+	p.SetGain(1 << 10)
+	p.SetSrcFFT(false)
+	p.SetFFT(false, 0)
+	p.dwsz_cfg.Store(0)
+	p.SetSaturationLimits(-0x8000, 0x7FFF)
+	p.Clear(EvAll)
+}
+
+// StartDirSearch resets and enables the direction search path.
+func (d *Driver) StartDirSearch() {
+	d.p.ResetDirSearch()
+	d.p.Clear(DirReady)
+	d.p.EnableDirSearch()
+}
+
+// StartVoice resets the voice path, selects the dir direction and enables
+// the voice stream generation.
+func (d *Driver) StartVoice(dir int) {
+	p := d.p
+	p.ResetVoice()
+	p.SetTargetDir(dir)
+	p.UpdateVoiceDir()
+	p.Clear(VoiceReady)
+	p.EnableVoice()
+}
+
+// SetVoiceDir switches the voice stream to the dir direction.
+func (d *Driver) SetVoiceDir(dir int) {
+	d.p.SetTargetDir(dir)
+	d.p.UpdateVoiceDir()
+}
+
+// The APU output registers are read by DMAC using 64-bit transfers (four
+// 16-bit samples per transfer).
+const dmaCtl = dma.SrcW64 | dma.DstW64 | dma.SrcB4 | dma.DstB4 | dma.SrcNoInc
+
+func read(dd *dma.Driver, dr, buf unsafe.Pointer, n int) error {
+	if dd == nil {
+		return ErrNoDMA
+	}
+	if uintptr(buf)&7 != 0 || n&3 != 0 {
+		panic("apu: unaligned buffer")
+	}
+	dd.Start(buf, dr, n/4, dmaCtl, dma.PTM)
+	return dd.Wait()
+}
+
+// ReadDir waits for the next block of the direction search data and reads it
+// into buf. Use Loudest to find the direction of the sound source.
+func (d *Driver) ReadDir(buf *DirData) error {
+	d.p.Clear(DirReady)
+	return read(d.dird, unsafe.Pointer(&d.p.sobuf), unsafe.Pointer(buf), NumDir*DirLen)
+}
+
+// ReadVoice reads len(buf) samples of the beamformed voice stream. The
+// len(buf) must be a multiple of 4 and buf must be 8-byte aligned (VoiceLen
+// is the natural block size).
+func (d *Driver) ReadVoice(buf []int16) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	d.p.Clear(VoiceReady)
+	return read(d.vocd, unsafe.Pointer(&d.p.vobuf), unsafe.Pointer(&buf[0]), len(buf))
+}
+
+// Loudest returns the direction with the highest energy in data and the
+// energies of all directions.
+func Loudest(data *DirData) (dir int, energy [NumDir]uint64) {
+	for i := range data {
+		var e uint64
+		for _, s := range data[i] {
+			e += uint64(int32(s) * int32(s))
+		}
+		energy[i] = e
+		if e > energy[dir] {
+			dir = i
+		}
+	}
+	return
+}
@@ -0,0 +1,292 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package apu provides interface to the Audio Processing Unit.
+//
+// The APU is a part of the I2S0 peripheral. It processes the samples received
+// by up to 4 I2S0 channels (8 sound channels: sound channel 2n is the left and
+// 2n+1 is the right sample of the I2S0 channel n). It performs the sound
+// direction searching (the delay-and-sum beamforming in 16 directions) and
+// generates the beamformed voice stream for the selected direction. The I2S0
+// receiver must be configured and enabled (see hal/i2s) but the I2S0 Rx DMA
+// should not be used.
+//
+// The processing paths are as follows:
+//
+//	direction search: sound channels -> pre FIR0 -> delay & sum -> post FIR0 -> DirReady
+//	voice stream:     sound channels -> pre FIR1 -> delay & sum -> post FIR1 -> VoiceReady
+package apu
+
+import (
+	"embedded/mmio"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/p/mmap"
+)
+
+// Periph represents the APU peripheral.
+type Periph struct {
+	ch_cfg   mmio.U32
+	ctl      mmio.U32
+	dir_bidx [NumDir][2]mmio.U32
+	fir      [4][9]mmio.U32
+	dwsz_cfg mmio.U32
+	fft_cfg  mmio.U32
+	sobuf    mmio.U32
+	vobuf    mmio.U32
+	int_stat mmio.U32
+	int_mask mmio.U32
+	sat_cnt  mmio.U32
+	sat_lim  mmio.U32
+}
+
+// NumDir is the number of sound directions.
+const NumDir = 16
+
+// NumCh is the number of sound channels.
+const NumCh = 8
+
+// APU returns the APU peripheral.
+func APU() *Periph {
+	return (*Periph)(unsafe.Pointer(mmap.APU_BASE))
+}
+
+// ch_cfg bits
+const (
+	chEn      = 0xFF << 0
+	tarDir    = 0xF << 8
+	gain      = 0x7FF << 12
+	srcFFT    = 1 << 24
+	weChEn    = 1 << 28
+	weTarDir  = 1 << 29
+	weGain    = 1 << 30
+	weSrcMode = 1 << 31
+	tarDirn   = 8
+	gainn     = 12
+	srcFFTn   = 24
+)
+
+// Channels returns the enabled sound channels (bit n corresponds to the
+// sound channel n).
+func (p *Periph) Channels() uint8 {
+	return uint8(p.ch_cfg.Load() & chEn)
+}
+
+// SetChannels enables the sound channels selected by mask (bit n corresponds
+// to the sound channel n) and disables the others.
+func (p *Periph) SetChannels(mask uint8) {
+	p.ch_cfg.Store(weChEn | uint32(mask))
+}
+
+// TargetDir returns the direction of the voice stream.
+func (p *Periph) TargetDir() int {
+	return int(p.ch_cfg.Load() & tarDir >> tarDirn)
+}
+
+// SetTargetDir selects the direction (0 to 15) of the voice stream. Use
+// UpdateVoiceDir to switch the voice stream to the new direction.
+func (p *Periph) SetTargetDir(dir int) {
+	p.ch_cfg.Store(weTarDir | uint32(dir&15)<<tarDirn)
+}
+
+// Gain returns the audio gain as an unsigned 1.10 fixed-point number.
+func (p *Periph) Gain() int {
+	return int(p.ch_cfg.Load() & gain >> gainn)
+}
+
+// SetGain sets the gain applied to the sum of the sound channels. The g is
+// an unsigned 1.10 fixed-point number (1 << 10 means 1.0).
+func (p *Periph) SetGain(g int) {
+	p.ch_cfg.Store(weGain | uint32(g)<<gainn&gain)
+}
+
+// SetSrcFFT selects the source of the audio data: the APU internal buffer
+// (false) or the FFT result buffer (true).
+func (p *Periph) SetSrcFFT(fft bool) {
+	var v uint32
+	if fft {
+		v = srcFFT
+	}
+	p.ch_cfg.Store(weSrcMode | v)
+}
+
+// ctl bits
+const (
+	dirSearchEn   = 1 << 0
+	searchPathRst = 1 << 1
+	streamGenEn   = 1 << 4
+	voicePathRst  = 1 << 5
+	updVoiceDir   = 1 << 6
+	weDirSearchEn = 1 << 8
+	weSearchRst   = 1 << 9
+	weStreamGen   = 1 << 10
+	weVoiceRst    = 1 << 11
+	weUpdVoiceDir = 1 << 12
+)
+
+// EnableDirSearch enables the sound direction searching.
+func (p *Periph) EnableDirSearch() {
+	p.ctl.Store(weDirSearchEn | dirSearchEn)
+}
+
+// DisableDirSearch disables the sound direction searching.
+func (p *Periph) DisableDirSearch() {
+	p.ctl.Store(weDirSearchEn)
+}
+
+// ResetDirSearch resets the direction searching path.
+func (p *Periph) ResetDirSearch() {
+	p.ctl.Store(weSearchRst | searchPathRst)
+}
+
+// EnableVoice enables the voice stream generation.
+func (p *Periph) EnableVoice() {
+	p.ctl.Store(weStreamGen | streamGenEn)
+}
+
+// DisableVoice disables the voice stream generation.
+func (p *Periph) DisableVoice() {
+	p.ctl.Store(weStreamGen)
+}
+
+// ResetVoice resets the voice stream generation path.
+func (p *Periph) ResetVoice() {
+	p.ctl.Store(weVoiceRst | voicePathRst)
+}
+
+// UpdateVoiceDir switches the voice stream to the direction set by
+// SetTargetDir.
+func (p *Periph) UpdateVoiceDir() {
+	p.ctl.Store(weUpdVoiceDir | updVoiceDir)
+}
+
+// Delays returns the delays (in samples) of the sound channels used to form
+// the dir direction.
+func (p *Periph) Delays(dir int) (delays [NumCh]uint8) {
+	for i := 0; i < 2; i++ {
+		v := p.dir_bidx[dir][i].Load()
+		for k := 0; k < 4; k++ {
+			delays[i*4+k] = uint8(v>>(k*8)) & 0x3F
+		}
+	}
+	return
+}
+
+// SetDelays sets the delays (in samples, 0 to 63) of the sound channels used
+// to form the dir direction.
+func (p *Periph) SetDelays(dir int, delays *[NumCh]uint8) {
+	for i := 0; i < 2; i++ {
+		var v uint32
+		for k := 3; k >= 0; k-- {
+			v = v<<8 | uint32(delays[i*4+k]&0x3F)
+		}
+		p.dir_bidx[dir][i].Store(v)
+	}
+}
+
+// FIR selects one of the APU FIR filters.
+type FIR uint8
+
+const (
+	DirPreFIR    FIR = 0 // direction search path pre-filter (pre FIR0)
+	DirPostFIR   FIR = 1 // direction search path post-filter (post FIR0)
+	VoicePreFIR  FIR = 2 // voice path pre-filter (pre FIR1)
+	VoicePostFIR FIR = 3 // voice path post-filter (post FIR1)
+)
+
+// FIRLen is the number of taps of the FIR filter. The filters are intended
+// to be symmetric (linear phase) but the hardware does not enforce it.
+const FIRLen = 17
+
+// SetFIR sets the coefficients of the f filter. Every coefficient register
+// holds two taps (even tap in the low half). The high half of the last
+// register is unused.
+func (p *Periph) SetFIR(f FIR, coef *[FIRLen]int16) {
+	for i := range p.fir[f] {
+		v := uint32(uint16(coef[2*i]))
+		if 2*i+1 < FIRLen {
+			v |= uint32(uint16(coef[2*i+1])) << 16
+		}
+		p.fir[f][i].Store(v)
+	}
+}
+
+// FIRCoef returns the coefficients of the f filter.
+func (p *Periph) FIRCoef(f FIR) (coef [FIRLen]int16) {
+	for i := range p.fir[f] {
+		v := p.fir[f][i].Load()
+		coef[2*i] = int16(v)
+		if 2*i+1 < FIRLen {
+			coef[2*i+1] = int16(v >> 16)
+		}
+	}
+	return
+}
+
+// SetDownsize sets the down-sizing ratios of the direction search path and
+// the voice path. The n means the sample rate divided by n+1 (n = 0 to 15).
+func (p *Periph) SetDownsize(dir, voice int) {
+	p.dwsz_cfg.StoreBits(0xFF, uint32(dir&15)|uint32(voice&15)<<4)
+}
+
+// SetShift sets the number of bits the input samples are shifted right
+// (0 to 31) before processing.
+func (p *Periph) SetShift(bits int) {
+	p.dwsz_cfg.StoreBits(0x1F<<8, uint32(bits&0x1F)<<8)
+}
+
+// SetFFT enables or disables the FFT unit in the voice path and sets its
+// shift factor (9 bits).
+func (p *Periph) SetFFT(enable bool, shift int) {
+	v := uint32(shift & 0x1FF)
+	if enable {
+		v |= 1 << 12
+	}
+	p.fft_cfg.Store(v)
+}
+
+// Event represents the APU events.
+type Event uint8
+
+const (
+	DirReady   Event = 1 << 0 // direction search data ready
+	VoiceReady Event = 1 << 1 // voice stream data ready
+
+	EvAll = DirReady | VoiceReady
+)
+
+// Events returns the pending events.
+func (p *Periph) Events() Event {
+	return Event(p.int_stat.Load()) & EvAll
+}
+
+// Clear clears the ev events.
+func (p *Periph) Clear(ev Event) {
+	p.int_stat.Store(uint32(ev))
+}
+
+// IRQEnabled returns events that are enabled to generate interrupt request
+// (the APU uses the I2S0 interrupt).
+func (p *Periph) IRQEnabled() Event {
+	return ^Event(p.int_mask.Load()) & EvAll
+}
+
+// SetIRQ sets the events that are enabled to generate interrupt request.
+func (p *Periph) SetIRQ(ev Event) {
+	p.int_mask.Store(uint32(^ev & EvAll))
+}
+
+// Saturation returns the number of saturated samples and the total number of
+// samples counted since the last call of SetSaturationLimits.
+func (p *Periph) Saturation() (sat, total int) {
+	v := p.sat_cnt.Load()
+	return int(v & 0xFFFF), int(v >> 16)
+}
+
+// SetSaturationLimits sets the limits of the voice samples (the samples out
+// of range are saturated) and resets the saturation counters.
+func (p *Periph) SetSaturationLimits(lo, hi int16) {
+	p.sat_lim.Store(uint32(uint16(hi)) | uint32(uint16(lo))<<16)
+	p.sat_cnt.Store(0)
+}
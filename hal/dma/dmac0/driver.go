@@ -0,0 +1,89 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dmac0 provides ready to use drivers for the DMAC channels.
+package dmac0
+
+import (
+	"embedded/rtos"
+	"sync"
+	_ "unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/dma"
+	"github.com/embeddedgo/kendryte/hal/irq"
+)
+
+var (
+	mx      sync.Mutex
+	drivers [dma.NumChannel]*dma.Driver
+)
+
+// Driver returns a ready to use driver for the n-th DMAC channel. Driver does
+// not allocate the channel (see Alloc).
+func Driver(n int) *dma.Driver {
+	mx.Lock()
+	d := drivers[n]
+	if d == nil {
+		p := dma.DMAC(0)
+		p.EnableClock()
+		p.Enable()
+		d = dma.NewDriver(p.Channel(n)) // must before ir.Enable
+		drivers[n] = d
+		ctx := irq.M0
+		ir := irq.DMA0 + rtos.IRQ(n)
+		if ir&1 != 0 {
+			ctx = irq.M1
+		}
+		ir.Enable(rtos.IntPrioLow, ctx)
+	}
+	mx.Unlock()
+	return d
+}
+
+// Alloc allocates a free DMAC channel and returns a ready to use driver for
+// it. It returns nil if there is no free channel. Use d.Channel().Free() to
+// release the allocated channel.
+func Alloc() *dma.Driver {
+	c := dma.DMAC(0).AllocChannel()
+	if c == nil {
+		return nil
+	}
+	return Driver(c.Num())
+}
+
+// AllocFor works like Alloc but also connects the allocated channel to the
+// req request line. It returns nil if there is no free channel or req is
+// already connected to another channel.
+func AllocFor(req dma.Request) *dma.Driver {
+	c := dma.DMAC(0).AllocChannelFor(req)
+	if c == nil {
+		return nil
+	}
+	return Driver(c.Num())
+}
+
+//go:interrupthandler
+func _DMA0_Handler() { drivers[0].ISR() }
+
+//go:interrupthandler
+func _DMA1_Handler() { drivers[1].ISR() }
+
+//go:interrupthandler
+func _DMA2_Handler() { drivers[2].ISR() }
+
+//go:interrupthandler
+func _DMA3_Handler() { drivers[3].ISR() }
+
+//go:interrupthandler
+func _DMA4_Handler() { drivers[4].ISR() }
+
+//go:interrupthandler
+func _DMA5_Handler() { drivers[5].ISR() }
+
+//go:linkname _DMA0_Handler IRQ27_Handler
+//go:linkname _DMA1_Handler IRQ28_Handler
+//go:linkname _DMA2_Handler IRQ29_Handler
+//go:linkname _DMA3_Handler IRQ30_Handler
+//go:linkname _DMA4_Handler IRQ31_Handler
+//go:linkname _DMA5_Handler IRQ32_Handler
@@ -0,0 +1,236 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dma
+
+import (
+	"embedded/rtos"
+	"runtime"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+type DriverError uint8
+
+const (
+	// ErrTimeout is returned if timeout occured. It means that the transfer
+	// has been interrupted and you can not determine the exact number of data
+	// items transferred.
+	ErrTimeout DriverError = iota + 1
+)
+
+// Error implements error interface.
+func (e DriverError) Error() string {
+	switch e {
+	case ErrTimeout:
+		return "dma: timeout"
+	}
+	return ""
+}
+
+// Driver is an interrupt based driver for a DMAC channel. It starts transfers
+// and waits for their completion using the Done interrupt. The driver
+// supports one goroutine at a time.
+type Driver struct {
+	c       *Channel
+	err     uint32
+	isr     uint32
+	done    rtos.Note
+	timeout time.Duration
+
+	nblk uint32 // number of blocks done (see IOCBlk)
+	rblk uint32 // number of blocks reported by WaitBlock
+	blk  rtos.Note
+	ring []LLI // circular list of the transfer started by StartRing
+}
+
+// NewDriver returns a new driver for c.
+func NewDriver(c *Channel) *Driver {
+	c.DisableIRQ(EvAll, ErrAll)
+	c.Clear(EvAll, ErrAll)
+	return &Driver{c: c, timeout: -1}
+}
+
+func (d *Driver) Channel() *Channel {
+	return d.c
+}
+
+// SetTimeout sets the timeout used by Wait (and all methods that call Wait).
+func (d *Driver) SetTimeout(timeout time.Duration) {
+	d.timeout = timeout
+}
+
+// ISR handles the channel interrupts.
+func (d *Driver) ISR() {
+	atomic.StoreUint32(&d.isr, 1)
+	ev, err := d.c.Status()
+	d.c.Clear(ev, err)
+	if d.ring != nil && (ev&BlockDone != 0 || err&ErrLLIInval != 0) {
+		for i := range d.ring {
+			d.ring[i].revalidate()
+		}
+		if err&ErrLLIInval != 0 {
+			// The ISR was too late and the DMAC halted on the item written
+			// back at the end of the previous block. Count the stall as a
+			// lost block and resume the transfer.
+			err &^= ErrLLIInval
+			atomic.AddUint32(&d.nblk, 1)
+			d.c.ResumeBlock()
+		}
+	}
+	if err != 0 {
+		d.c.Disable()
+		atomic.StoreUint32(&d.err, uint32(err))
+	}
+	if ev&BlockDone != 0 {
+		atomic.AddUint32(&d.nblk, 1)
+		d.blk.Wakeup()
+	}
+	if ev&Done != 0 || err != 0 {
+		d.c.DisableIRQ(Done|BlockDone, ErrAll)
+		d.done.Wakeup()
+		d.blk.Wakeup()
+	}
+	atomic.StoreUint32(&d.isr, 0)
+}
+
+func (d *Driver) start(ring []LLI) {
+	d.ring = ring
+	d.err = 0
+	d.nblk, d.rblk = 0, 0
+	d.done.Clear()
+	d.blk.Clear()
+	d.c.Clear(EvAll, ErrAll)
+	d.c.EnableIRQ(Done|BlockDone, ErrAll)
+	d.c.Enable()
+}
+
+// Start starts the single block transfer of n data items from src to dst.
+// The ctl and cfg describe the transfer (see Channel.SetCtrl and
+// Channel.SetConf). Use Wait to wait for the end of the transfer.
+func (d *Driver) Start(dst, src unsafe.Pointer, n int, ctl Ctrl, cfg Conf) {
+	c := d.c
+	c.SetConf(cfg &^ LL)
+	c.SetCtrl(ctl)
+	c.SetSrcAddr(src)
+	c.SetDstAddr(dst)
+	c.SetLen(n)
+	d.start(nil)
+}
+
+// StartLL starts the linked list multi-block transfer described by the list
+// of items that begins with first. Use Wait to wait for the end of the
+// transfer.
+func (d *Driver) StartLL(first *LLI, cfg Conf) {
+	c := d.c
+	c.SetConf(cfg | LL)
+	c.SetLLP(first)
+	d.start(nil)
+}
+
+// StartRing starts the endless linked list multi-block transfer described by
+// the ring of items (the last item must point to the first one). The DMAC
+// clears the LLIValid bit of every item it has processed so the driver sets
+// it again in the BlockDone interrupt handler (all items should have the
+// IOCBlk bit set). Use WaitBlock to wait for the subsequent blocks and Stop to
+// terminate the transfer.
+func (d *Driver) StartRing(ring []LLI, cfg Conf) {
+	c := d.c
+	c.SetConf(cfg | LL)
+	c.SetLLP(&ring[0])
+	d.start(ring)
+}
+
+// Wait waits for the end of the transfer started by Start or StartLL. It
+// returns ErrTimeout if the transfer has not been finished before the timeout
+// (the channel is disabled in such case) or an Error if the DMAC reported an
+// error.
+func (d *Driver) Wait() error {
+	if !d.done.Sleep(d.timeout) {
+		d.Stop()
+		return ErrTimeout
+	}
+	if err := atomic.LoadUint32(&d.err); err != 0 {
+		return Error(err)
+	}
+	return nil
+}
+
+// WaitBlock waits for the end of the next block of the transfer started by
+// Start or StartLL. The BlockDone event is generated only for the blocks with
+// the IOCBlk bit set in their control word. WaitBlock returns the number of
+// blocks done since the previous call of WaitBlock (more than 1 means the
+// caller was too slow to handle all blocks one by one, see also StartRing).
+// It returns
+// ErrTimeout if no block has been done before the timeout (the channel is
+// disabled in such case) or an Error if the DMAC reported an error.
+func (d *Driver) WaitBlock() (int, error) {
+	for atomic.LoadUint32(&d.nblk) == d.rblk {
+		if atomic.LoadUint32(&d.err) != 0 {
+			break
+		}
+		d.blk.Clear()
+		if atomic.LoadUint32(&d.nblk) != d.rblk {
+			break
+		}
+		if !d.blk.Sleep(d.timeout) {
+			d.Stop()
+			return 0, ErrTimeout
+		}
+	}
+	if err := atomic.LoadUint32(&d.err); err != 0 {
+		return 0, Error(err)
+	}
+	nblk := atomic.LoadUint32(&d.nblk)
+	n := int(nblk - d.rblk)
+	d.rblk = nblk
+	return n, nil
+}
+
+// Stop disables the channel and waits until it is disabled. Use it to
+// terminate an endless (e.g. circular linked list) transfer.
+func (d *Driver) Stop() {
+	d.c.DisableIRQ(Done|BlockDone, ErrAll)
+	d.c.Disable()
+	for d.c.Enabled() || atomic.LoadUint32(&d.isr) != 0 {
+		runtime.Gosched()
+	}
+	d.ring = nil
+}
+
+// Copy copies n bytes from src to dst. It selects the widest transfer width
+// allowed by the alignment of src, dst and n.
+func (d *Driver) Copy(dst, src unsafe.Pointer, n int) error {
+	var (
+		ctl   Ctrl
+		shift uint
+	)
+	switch a := uintptr(dst) | uintptr(src) | uintptr(n); {
+	case a&7 == 0:
+		ctl, shift = SrcW64|DstW64, 3
+	case a&3 == 0:
+		ctl, shift = SrcW32|DstW32, 2
+	case a&1 == 0:
+		ctl, shift = SrcW16|DstW16, 1
+	default:
+		ctl, shift = SrcW8|DstW8, 0
+	}
+	ctl |= SrcB4 | DstB4
+	for n > 0 {
+		m := n >> shift
+		if m > MaxLen {
+			m = MaxLen
+		}
+		d.Start(dst, src, m, ctl, MTM)
+		if err := d.Wait(); err != nil {
+			return err
+		}
+		m <<= shift
+		dst = unsafe.Add(dst, m)
+		src = unsafe.Add(src, m)
+		n -= m
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dma
+
+import "unsafe"
+
+// LLI represents the linked list item (a block descriptor) used by the
+// linked list multi-block transfers. The DMAC requires LLIs to be aligned to
+// 64 bytes. Use NewLLIs to allocate properly aligned items.
+type LLI struct {
+	sar        uint64
+	dar        uint64
+	block_ts   uint64
+	llp        uint64
+	ctl        uint64
+	sstat      uint32
+	dstat      uint32
+	llp_status uint64
+	_          uint64
+}
+
+// NewLLIs allocates n properly aligned linked list items.
+func NewLLIs(n int) []LLI {
+	const align = unsafe.Sizeof(LLI{}) // 64
+	buf := make([]LLI, n+1)
+	offset := (align - uintptr(unsafe.Pointer(&buf[0]))&(align-1)) & (align - 1)
+	return unsafe.Slice((*LLI)(unsafe.Add(unsafe.Pointer(&buf[0]), offset)), n)
+}
+
+// Set sets the item to describe the transfer of n data items from src to dst.
+// The next points to the next item in the list or it is nil for the last item.
+// Set sets the LLIValid bit in ctl and also the LLILast bit if next is nil.
+func (l *LLI) Set(dst, src unsafe.Pointer, n int, ctl Ctrl, next *LLI) {
+	if uint(n-1) >= MaxLen {
+		panic("dma: bad block length")
+	}
+	ctl |= LLIValid
+	if next == nil {
+		ctl |= LLILast
+	}
+	l.sar = uint64(uintptr(src))
+	l.dar = uint64(uintptr(dst))
+	l.block_ts = uint64(n - 1)
+	l.llp = uint64(uintptr(unsafe.Pointer(next)))
+	l.ctl = uint64(ctl)
+	l.llp_status = 0
+}
+
+// revalidate sets the LLIValid bit cleared by DMAC when it writes the item
+// back at the end of the block.
+func (l *LLI) revalidate() {
+	l.ctl |= uint64(LLIValid)
+}
+
+// Ctrl returns the item's CTL value.
+func (l *LLI) Ctrl() Ctrl {
+	return Ctrl(l.ctl)
+}
@@ -0,0 +1,405 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dma provides interface to the Direct Memory Access Controller.
+//
+// The K210 DMAC has six independent channels. Every channel can perform
+// memory to memory, memory to peripheral and peripheral to memory transfers
+// using a single block or a linked list of blocks.
+package dma
+
+import (
+	"embedded/mmio"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/bus"
+	"github.com/embeddedgo/kendryte/p/mmap"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// Synopsys DW_axi_dmac
+
+// Periph represents the DMA controller.
+type Periph struct {
+	id               mmio.U64
+	compver          mmio.U64
+	cfg              mmio.U64
+	chen             mmio.U64
+	_                [2]uint64
+	intstatus        mmio.U64
+	com_intclear     mmio.U64
+	com_intstatus_en mmio.U64
+	com_intsignal_en mmio.U64
+	com_intstatus    mmio.U64
+	reset            mmio.U64
+	_                [20]uint64
+	ch               [NumChannel]Channel
+}
+
+// NumChannel is the number of DMAC channels.
+const NumChannel = 6
+
+func DMAC(n int) *Periph {
+	if n != 0 {
+		panic("dma: bad number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.DMAC_BASE))
+}
+
+func (p *Periph) Bus() bus.Bus {
+	return bus.AXI
+}
+
+func (p *Periph) EnableClock() {
+	mx := &internal.MX.SYSCTL
+	mx.CLK_EN_PERI.Lock()
+	sysctl.SYSCTL().DMA_CLK_EN().Set()
+	mx.CLK_EN_PERI.Unlock()
+}
+
+func (p *Periph) DisableClock() {
+	mx := &internal.MX.SYSCTL
+	mx.CLK_EN_PERI.Lock()
+	sysctl.SYSCTL().DMA_CLK_EN().Clear()
+	mx.CLK_EN_PERI.Unlock()
+}
+
+func (p *Periph) Reset() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.PERI_RESET.Lock()
+	sc.DMA_RESET().Set()
+	mx.PERI_RESET.Unlock()
+
+	time.Sleep(10 * time.Microsecond)
+
+	mx.PERI_RESET.Lock()
+	sc.DMA_RESET().Clear()
+	mx.PERI_RESET.Unlock()
+}
+
+const (
+	dmacEn uint64 = 1 << 0
+	intEn  uint64 = 1 << 1
+)
+
+// Enable enables the DMA controller and the interrupt generation.
+func (p *Periph) Enable() {
+	p.cfg.SetBits(dmacEn | intEn)
+}
+
+// Disable disables the DMA controller.
+func (p *Periph) Disable() {
+	p.cfg.ClearBits(dmacEn | intEn)
+}
+
+// Channel returns the n-th channel of the controller.
+func (p *Periph) Channel(n int) *Channel {
+	return &p.ch[n]
+}
+
+// Status returns the bitmask of channels with pending interrupts.
+func (p *Periph) Status() uint32 {
+	return uint32(p.intstatus.Load() & (1<<NumChannel - 1))
+}
+
+var chanUsed uint32
+
+// AllocChannel allocates a free channel. It returns nil if all channels are
+// in use. AllocChannel is safe for concurrent use by multiple goroutines
+// running on any hart. Use Channel.Free to release the allocated channel.
+func (p *Periph) AllocChannel() *Channel {
+	for {
+		used := atomic.LoadUint32(&chanUsed)
+		n := 0
+		for used&(1<<uint(n)) != 0 {
+			if n++; n == NumChannel {
+				return nil
+			}
+		}
+		if atomic.CompareAndSwapUint32(&chanUsed, used, used|1<<uint(n)) {
+			return &p.ch[n]
+		}
+	}
+}
+
+// Channel represents the DMAC channel.
+type Channel struct {
+	sar          mmio.U64
+	dar          mmio.U64
+	block_ts     mmio.U64
+	ctl          mmio.U64
+	cfg          mmio.U64
+	llp          mmio.U64
+	status       mmio.U64
+	swhssrc      mmio.U64
+	swhsdst      mmio.U64
+	blk_tfr      mmio.U64
+	axi_id       mmio.U64
+	axi_qos      mmio.U64
+	_            [4]uint64
+	intstatus_en mmio.U64
+	intstatus    mmio.U64
+	intsignal_en mmio.U64
+	intclear     mmio.U64
+	_            [12]uint64
+}
+
+// Num returns the channel number.
+func (c *Channel) Num() int {
+	return int((uintptr(unsafe.Pointer(c)) - mmap.DMAC_BASE - 0x100) / 0x100)
+}
+
+// Periph returns the controller this channel belongs to.
+func (c *Channel) Periph() *Periph {
+	return DMAC(0)
+}
+
+// Free releases the channel allocated by AllocChannel or AllocChannelFor. It
+// disconnects the request line connected to the channel.
+func (c *Channel) Free() {
+	c.Disconnect()
+	mask := uint32(1) << uint(c.Num())
+	for {
+		used := atomic.LoadUint32(&chanUsed)
+		if used&mask == 0 {
+			panic("dma: free of unallocated channel")
+		}
+		if atomic.CompareAndSwapUint32(&chanUsed, used, used&^mask) {
+			return
+		}
+	}
+}
+
+// Enable enables the channel which starts the configured transfer.
+func (c *Channel) Enable() {
+	n := uint(c.Num())
+	c.Periph().chen.Store(1<<n | 1<<(n+8))
+}
+
+// Disable disables the channel. The transfer in progress is terminated after
+// the current AXI burst. Use Enabled to wait for the channel to become idle.
+func (c *Channel) Disable() {
+	n := uint(c.Num())
+	c.Periph().chen.Store(1 << (n + 8))
+}
+
+// Enabled reports whether the channel is enabled. The hardware clears the
+// channel enable bit when the transfer is done.
+func (c *Channel) Enabled() bool {
+	return c.Periph().chen.Load()>>uint(c.Num())&1 != 0
+}
+
+// ResumeBlock requests the channel to resume the linked list transfer halted
+// because of the invalid linked list item (see ErrLLIInval). The item must be
+// made valid before.
+func (c *Channel) ResumeBlock() {
+	c.blk_tfr.Store(1)
+}
+
+// Ctrl represents the channel control register (CTL).
+type Ctrl uint64
+
+const (
+	SrcM2    Ctrl = 1 << 0 // use AXI master 2 for source
+	DstM2    Ctrl = 1 << 2 // use AXI master 2 for destination
+	SrcNoInc Ctrl = 1 << 4 // do not increment the source address
+	DstNoInc Ctrl = 1 << 6 // do not increment the destination address
+
+	SrcW8  Ctrl = 0 << 8 // 8-bit source transfer width
+	SrcW16 Ctrl = 1 << 8 // 16-bit source transfer width
+	SrcW32 Ctrl = 2 << 8 // 32-bit source transfer width
+	SrcW64 Ctrl = 3 << 8 // 64-bit source transfer width
+
+	DstW8  Ctrl = 0 << 11 // 8-bit destination transfer width
+	DstW16 Ctrl = 1 << 11 // 16-bit destination transfer width
+	DstW32 Ctrl = 2 << 11 // 32-bit destination transfer width
+	DstW64 Ctrl = 3 << 11 // 64-bit destination transfer width
+
+	SrcB1  Ctrl = 0 << 14 // source burst transaction length: 1 data item
+	SrcB4  Ctrl = 1 << 14 // source burst transaction length: 4 data items
+	SrcB8  Ctrl = 2 << 14 // source burst transaction length: 8 data items
+	SrcB16 Ctrl = 3 << 14 // source burst transaction length: 16 data items
+
+	DstB1  Ctrl = 0 << 18 // dest. burst transaction length: 1 data item
+	DstB4  Ctrl = 1 << 18 // dest. burst transaction length: 4 data items
+	DstB8  Ctrl = 2 << 18 // dest. burst transaction length: 8 data items
+	DstB16 Ctrl = 3 << 18 // dest. burst transaction length: 16 data items
+
+	IOCBlk   Ctrl = 1 << 58 // BlockDone interrupt at the end of block
+	LLILast  Ctrl = 1 << 62 // the last linked list item
+	LLIValid Ctrl = 1 << 63 // linked list item is valid
+
+	SrcW = SrcW64 // source transfer width mask
+	DstW = DstW64 // destination transfer width mask
+)
+
+func (c *Channel) Ctrl() Ctrl {
+	return Ctrl(c.ctl.Load())
+}
+
+func (c *Channel) SetCtrl(ctl Ctrl) {
+	c.ctl.Store(uint64(ctl))
+}
+
+// Conf represents the channel configuration register (CFG).
+type Conf uint64
+
+const (
+	SrcLL Conf = 3 << 0 // source uses linked list multi-block transfer
+	DstLL Conf = 3 << 2 // destination uses linked list multi-block transfer
+
+	MTM Conf = 0 << 32 // memory to memory
+	MTP Conf = 1 << 32 // memory to peripheral
+	PTM Conf = 2 << 32 // peripheral to memory
+	PTP Conf = 3 << 32 // peripheral to peripheral
+
+	SrcSWHS Conf = 1 << 35 // software handshaking for source
+	DstSWHS Conf = 1 << 36 // software handshaking for destination
+
+	Prio0 Conf = 0 << 49 // channel priority: lowest
+	Prio1 Conf = 1 << 49
+	Prio2 Conf = 2 << 49
+	Prio3 Conf = 3 << 49
+	Prio4 Conf = 4 << 49
+	Prio5 Conf = 5 << 49
+	Prio6 Conf = 6 << 49
+	Prio7 Conf = 7 << 49 // channel priority: highest
+
+	LL   = SrcLL | DstLL // linked list multi-block transfer
+	Prio = Prio7         // channel priority mask
+
+	Dir Conf = 7 << 32 // transfer type mask
+
+	srcPern      = 39
+	dstPern      = 44
+	osrLmt  Conf = 3<<55 | 3<<59 // 4 outstanding requests (default)
+)
+
+func (c *Channel) Conf() Conf {
+	return Conf(c.cfg.Load()) & (LL | Dir | SrcSWHS | DstSWHS | Prio)
+}
+
+// SetConf configures the channel. The channel uses its own hardware
+// handshaking interface (with the same number as the channel) which must be
+// connected to the peripheral request line using Connect.
+func (c *Channel) SetConf(cfg Conf) {
+	n := Conf(c.Num())
+	c.cfg.Store(uint64(cfg | n<<srcPern | n<<dstPern | osrLmt))
+}
+
+// SetSrcAddr sets the source address.
+func (c *Channel) SetSrcAddr(a unsafe.Pointer) {
+	c.sar.Store(uint64(uintptr(a)))
+}
+
+// SetDstAddr sets the destination address.
+func (c *Channel) SetDstAddr(a unsafe.Pointer) {
+	c.dar.Store(uint64(uintptr(a)))
+}
+
+// MaxLen is the maximum number of data items in one block.
+const MaxLen = 1 << 22
+
+// SetLen sets the number of data items (of source transfer width) in the
+// block.
+func (c *Channel) SetLen(n int) {
+	if uint(n-1) >= MaxLen {
+		panic("dma: bad block length")
+	}
+	c.block_ts.Store(uint64(n - 1))
+}
+
+// Len returns the number of data items transferred in the current block.
+func (c *Channel) Len() int {
+	return int(c.status.Load() & (MaxLen - 1))
+}
+
+// SetLLP sets the address of the first linked list item.
+func (c *Channel) SetLLP(lli *LLI) {
+	c.llp.Store(uint64(uintptr(unsafe.Pointer(lli))))
+}
+
+// Event represents channel events.
+type Event uint16
+
+const (
+	BlockDone    Event = 1 << 0 // block transfer done (see IOCBlk)
+	Done         Event = 1 << 1 // DMA transfer done
+	SrcTransComp Event = 1 << 3 // source transaction complete
+	DstTransComp Event = 1 << 4 // destination transaction complete
+
+	EvAll = BlockDone | Done | SrcTransComp | DstTransComp
+)
+
+// Error represents channel errors.
+type Error uint16
+
+const (
+	ErrSrcDec   Error = 1 << 5  // source decode error
+	ErrDstDec   Error = 1 << 6  // destination decode error
+	ErrSrcSlv   Error = 1 << 7  // source slave error
+	ErrDstSlv   Error = 1 << 8  // destination slave error
+	ErrLLIRdDec Error = 1 << 9  // LLI read decode error
+	ErrLLIWrDec Error = 1 << 10 // LLI write decode error
+	ErrLLIRdSlv Error = 1 << 11 // LLI read slave error
+	ErrLLIWrSlv Error = 1 << 12 // LLI write slave error
+	ErrLLIInval Error = 1 << 13 // shadow register or LLI invalid
+
+	ErrAll = ErrSrcDec | ErrDstDec | ErrSrcSlv | ErrDstSlv | ErrLLIRdDec |
+		ErrLLIWrDec | ErrLLIRdSlv | ErrLLIWrSlv | ErrLLIInval
+)
+
+var errStr = [...]string{
+	"src decode",
+	"dst decode",
+	"src slave",
+	"dst slave",
+	"LLI read decode",
+	"LLI write decode",
+	"LLI read slave",
+	"LLI write slave",
+	"LLI invalid",
+}
+
+// Error implements error interface.
+func (e Error) Error() string {
+	s := "dma:"
+	for i, es := range errStr {
+		if e&(ErrSrcDec<<uint(i)) != 0 {
+			s += " " + es
+		}
+	}
+	return s + " error"
+}
+
+// Status returns the channel events and errors.
+func (c *Channel) Status() (Event, Error) {
+	st := c.intstatus.Load()
+	return Event(st) & EvAll, Error(st) & ErrAll
+}
+
+// Clear clears the specified events and errors.
+func (c *Channel) Clear(ev Event, err Error) {
+	c.intclear.Store(uint64(ev) | uint64(err))
+}
+
+// EnableIRQ enables generating interrupts by the specified events and errors.
+func (c *Channel) EnableIRQ(ev Event, err Error) {
+	mask := uint64(ev) | uint64(err)
+	c.intstatus_en.SetBits(mask)
+	c.intsignal_en.SetBits(mask)
+}
+
+// DisableIRQ disables generating interrupts by the specified events and
+// errors.
+func (c *Channel) DisableIRQ(ev Event, err Error) {
+	mask := uint64(ev) | uint64(err)
+	c.intsignal_en.ClearBits(mask)
+	c.intstatus_en.ClearBits(mask)
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dma
+
+import (
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// Request represents a peripheral DMA request line (handshake signal) that can
+// be connected to the hardware handshaking interface of a DMAC channel.
+type Request uint8
+
+const (
+	SPI0_RX       Request = 0
+	SPI0_TX       Request = 1
+	SPI1_RX       Request = 2
+	SPI1_TX       Request = 3
+	SPI2_RX       Request = 4
+	SPI2_TX       Request = 5
+	SPI3_RX       Request = 6
+	SPI3_TX       Request = 7
+	I2C0_RX       Request = 8
+	I2C0_TX       Request = 9
+	I2C1_RX       Request = 10
+	I2C1_TX       Request = 11
+	I2C2_RX       Request = 12
+	I2C2_TX       Request = 13
+	UART1_RX      Request = 14
+	UART1_TX      Request = 15
+	UART2_RX      Request = 16
+	UART2_TX      Request = 17
+	UART3_RX      Request = 18
+	UART3_TX      Request = 19
+	AES           Request = 20
+	SHA_RX        Request = 21
+	AI_RX         Request = 22
+	FFT_RX        Request = 23
+	FFT_TX        Request = 24
+	I2S0_TX       Request = 25
+	I2S0_RX       Request = 26
+	I2S1_TX       Request = 27
+	I2S1_RX       Request = 28
+	I2S2_TX       Request = 29
+	I2S2_RX       Request = 30
+	I2S0_BF_DIR   Request = 31 // APU direction data
+	I2S0_BF_VOICE Request = 32 // APU voice data
+
+	ReqNone Request = 0xFF // no request line connected
+)
+
+// chanReq stores the request lines connected to channels. It is protected by
+// the internal.MX.SYSCTL.DMA_SEL mutex.
+var chanReq = [NumChannel]Request{
+	ReqNone, ReqNone, ReqNone, ReqNone, ReqNone, ReqNone,
+}
+
+// Request returns the request line connected to the channel or ReqNone.
+func (c *Channel) Request() Request {
+	mx := &internal.MX.SYSCTL.DMA_SEL
+	mx.Lock()
+	req := chanReq[c.Num()]
+	mx.Unlock()
+	return req
+}
+
+// Connect connects the peripheral request line req to the channel hardware
+// handshaking interface. It returns false if req is already connected to
+// another channel. Connecting the request to the channel disconnects the
+// request previously connected to it.
+func (c *Channel) Connect(req Request) bool {
+	if req > I2S0_BF_VOICE {
+		panic("dma: bad request")
+	}
+	n := c.Num()
+	mx := &internal.MX.SYSCTL.DMA_SEL
+	mx.Lock()
+	for i, r := range chanReq {
+		if r == req && i != n {
+			mx.Unlock()
+			return false
+		}
+	}
+	chanReq[n] = req
+	setSel(n, uint32(req))
+	mx.Unlock()
+	return true
+}
+
+// Disconnect disconnects the request line connected to the channel.
+func (c *Channel) Disconnect() {
+	n := c.Num()
+	mx := &internal.MX.SYSCTL.DMA_SEL
+	mx.Lock()
+	chanReq[n] = ReqNone
+	setSel(n, selNone)
+	mx.Unlock()
+}
+
+// selNone is the DMA_SEL value that selects no request line (the values
+// above I2S0_BF_VOICE are unused).
+const selNone = 0x3F
+
+// setSel writes v to the DMA_SEL field of the channel n. It must be called
+// with the internal.MX.SYSCTL.DMA_SEL mutex locked.
+func setSel(n int, v uint32) {
+	sc := sysctl.SYSCTL()
+	if n < 5 {
+		shift := uint(n * 6)
+		sc.DMA_SEL0.StoreBits(0x3F<<shift, sysctl.DMA_SEL0(v)<<shift)
+	} else {
+		sc.DMA_SEL1.StoreBits(0x3F, sysctl.DMA_SEL1(v))
+	}
+}
+
+// AllocChannelFor works like AllocChannel but also connects the allocated
+// channel to the req request line. It returns nil if there is no free channel
+// or req is already connected to another channel.
+func (p *Periph) AllocChannelFor(req Request) *Channel {
+	c := p.AllocChannel()
+	if c == nil {
+		return nil
+	}
+	if !c.Connect(req) {
+		c.Free()
+		return nil
+	}
+	return c
+}
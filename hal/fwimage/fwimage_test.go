@@ -0,0 +1,172 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fwimage
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"hash"
+	"io"
+	"testing"
+)
+
+func testKeys() (KeyStore, []ed25519.PrivateKey) {
+	var ks KeyStore
+	var privs []ed25519.PrivateKey
+	for _, seed := range []byte{1, 2} {
+		priv := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+		ks = append(ks, priv.Public().(ed25519.PublicKey))
+		privs = append(privs, priv)
+	}
+	return ks, privs
+}
+
+func payload(n int) []byte {
+	p := make([]byte, n)
+	for i := range p {
+		p[i] = byte(i*7 + 3)
+	}
+	return p
+}
+
+func TestHeaderRoundTrip(t *testing.T) {
+	h := Header{
+		Magic: Magic, HdrVer: HeaderVersion, Flags: Signed,
+		Size: 1234, Version: 5, LoadAddr: 0x80000000, KeyID: 1,
+	}
+	b, _ := h.MarshalBinary()
+	if len(b) != HeaderSize {
+		t.Fatalf("len = %d", len(b))
+	}
+	var g Header
+	if err := g.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if g != h {
+		t.Fatalf("got %+v, want %+v", g, h)
+	}
+	b[40] = 1
+	if err := g.UnmarshalBinary(b); err != ErrHeader {
+		t.Fatalf("reserved byte: err = %v", err)
+	}
+	b[0] ^= 1
+	if err := g.UnmarshalBinary(b); err != ErrMagic {
+		t.Fatalf("magic: err = %v", err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ks, privs := testKeys()
+	// sizes around the read buffer and SHA-256 block boundaries
+	for _, n := range []int{0, 1, 55, 64, bufLen - HeaderSize, bufLen, 3*bufLen + 17} {
+		for _, signed := range []bool{false, true} {
+			var key ed25519.PrivateKey
+			if signed {
+				key = privs[1]
+			}
+			h := Header{Version: 3, KeyID: 1}
+			img := Seal(&h, payload(n), key)
+			v := &Verifier{Keys: ks, RequireSigned: signed}
+			got, err := v.Verify(bytes.NewReader(img), 0)
+			if err != nil {
+				t.Fatalf("n=%d signed=%v: %v", n, signed, err)
+			}
+			if *got != h {
+				t.Fatalf("n=%d: got %+v, want %+v", n, *got, h)
+			}
+		}
+	}
+}
+
+func TestVerifyOffset(t *testing.T) {
+	h := Header{}
+	img := Seal(&h, payload(100), nil)
+	flash := append(make([]byte, 4096), img...)
+	v := &Verifier{}
+	if _, err := v.Verify(bytes.NewReader(flash), 4096); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyReject(t *testing.T) {
+	ks, privs := testKeys()
+	seal := func(h Header, key ed25519.PrivateKey) []byte {
+		return Seal(&h, payload(300), key)
+	}
+	tests := []struct {
+		name string
+		img  []byte
+		v    Verifier
+		err  error
+	}{
+		{"payload", func() []byte {
+			img := seal(Header{}, privs[0])
+			img[HeaderSize+10] ^= 0x40
+			return img
+		}(), Verifier{Keys: ks}, ErrDigest},
+		{"header", func() []byte {
+			img := seal(Header{}, nil)
+			img[12] ^= 1 // Version
+			return img
+		}(), Verifier{}, ErrDigest},
+		{"signature", func() []byte {
+			img := seal(Header{}, privs[0])
+			img[len(img)-1] ^= 1
+			return img
+		}(), Verifier{Keys: ks}, ErrSignature},
+		{"wrong key", seal(Header{KeyID: 1}, privs[0]), Verifier{Keys: ks}, ErrSignature},
+		{"unknown key", seal(Header{KeyID: 7}, privs[0]), Verifier{Keys: ks}, ErrKey},
+		{"revoked key", seal(Header{KeyID: 0}, privs[0]), Verifier{Keys: KeyStore{nil, ks[1]}}, ErrKey},
+		{"unsigned", seal(Header{}, nil), Verifier{Keys: ks, RequireSigned: true}, ErrUnsigned},
+		{"rollback", seal(Header{Version: 1}, nil), Verifier{MinVersion: 2}, ErrRollback},
+		{"size", seal(Header{}, nil), Verifier{MaxSize: 256}, ErrSize},
+		{"truncated", func() []byte {
+			img := seal(Header{}, privs[0])
+			return img[:len(img)-1]
+		}(), Verifier{Keys: ks}, io.ErrUnexpectedEOF},
+		{"magic", func() []byte {
+			img := seal(Header{}, nil)
+			img[3] = 0
+			return img
+		}(), Verifier{}, ErrMagic},
+	}
+	for _, tc := range tests {
+		_, err := tc.v.Verify(bytes.NewReader(tc.img), 0)
+		if err != tc.err {
+			t.Errorf("%s: err = %v, want %v", tc.name, err, tc.err)
+		}
+	}
+}
+
+// resetHash records the Reset calls.
+type resetHash struct {
+	hash.Hash
+	reset bool
+}
+
+func (h *resetHash) Reset() {
+	h.reset = true
+	h.Hash.Reset()
+}
+
+func TestVerifyHash(t *testing.T) {
+	img := Seal(&Header{}, payload(3*bufLen), nil)
+	v := &Verifier{Hash: func(size int) hash.Hash { return nil }}
+	if _, err := v.Verify(bytes.NewReader(img), 0); err != ErrSize {
+		t.Errorf("nil hash: err = %v, want %v", err, ErrSize)
+	}
+	var md *resetHash
+	v.Hash = func(size int) hash.Hash {
+		md = &resetHash{Hash: Software(size)}
+		return md
+	}
+	_, err := v.Verify(bytes.NewReader(img[:2*bufLen]), 0)
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("truncated: err = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if !md.reset {
+		t.Error("truncated: hash not reset")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fwimage defines the firmware image format and verifies the images
+// stored in flash before they are run or accepted as an update.
+//
+// An image consists of the header, the payload and the trailer:
+//
+//	offset             size  content
+//	0                    64  header (see Header)
+//	64                 Size  payload
+//	64+Size              32  SHA-256 digest of the header and payload
+//	96+Size              64  Ed25519 signature of the digest (Signed only)
+//
+// The package depends only on the standard library. The digest is computed
+// using the hash function provided by the Verifier so the SHA-256 accelerator
+// can be used on K210 (see hal/sha256) and the crypto/sha256 package on the
+// host.
+package fwimage
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	HeaderSize    = 64
+	DigestSize    = 32
+	SignatureSize = 64
+
+	Magic         = 0x3157464B // "KFW1"
+	HeaderVersion = 1
+)
+
+// Flags describe the image.
+type Flags uint16
+
+const (
+	Signed Flags = 1 << 0 // trailer contains signature
+)
+
+// Header is the image header. All fields are stored in little-endian byte
+// order. The unused bytes at the end of the header must be zero.
+type Header struct {
+	Magic    uint32 // offset  0: Magic
+	HdrVer   uint16 // offset  4: HeaderVersion
+	Flags    Flags  // offset  6
+	Size     uint32 // offset  8: payload size
+	Version  uint32 // offset 12: firmware version (anti-rollback counter)
+	LoadAddr uint32 // offset 16: payload load/run address
+	KeyID    uint8  // offset 20: signing key index in the key store
+}
+
+var (
+	ErrMagic     = errors.New("fwimage: bad magic")
+	ErrHeader    = errors.New("fwimage: bad header")
+	ErrSize      = errors.New("fwimage: image too large")
+	ErrDigest    = errors.New("fwimage: digest mismatch")
+	ErrUnsigned  = errors.New("fwimage: unsigned image")
+	ErrKey       = errors.New("fwimage: unknown or revoked key")
+	ErrSignature = errors.New("fwimage: bad signature")
+	ErrRollback  = errors.New("fwimage: version too old")
+)
+
+// TrailerSize returns the size of the trailer of the image described by h.
+func (h *Header) TrailerSize() int {
+	if h.Flags&Signed != 0 {
+		return DigestSize + SignatureSize
+	}
+	return DigestSize
+}
+
+// ImageSize returns the total size of the image described by h.
+func (h *Header) ImageSize() int64 {
+	return HeaderSize + int64(h.Size) + int64(h.TrailerSize())
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (h *Header) MarshalBinary() ([]byte, error) {
+	b := make([]byte, HeaderSize)
+	h.put(b)
+	return b, nil
+}
+
+func (h *Header) put(b []byte) {
+	le := binary.LittleEndian
+	le.PutUint32(b[0:], h.Magic)
+	le.PutUint16(b[4:], h.HdrVer)
+	le.PutUint16(b[6:], uint16(h.Flags))
+	le.PutUint32(b[8:], h.Size)
+	le.PutUint32(b[12:], h.Version)
+	le.PutUint32(b[16:], h.LoadAddr)
+	b[20] = h.KeyID
+	clear(b[21:HeaderSize])
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// checks the magic number, the header version, the flags and the reserved
+// bytes.
+func (h *Header) UnmarshalBinary(b []byte) error {
+	if len(b) < HeaderSize {
+		return ErrHeader
+	}
+	le := binary.LittleEndian
+	if le.Uint32(b[0:]) != Magic {
+		return ErrMagic
+	}
+	h.Magic = Magic
+	h.HdrVer = le.Uint16(b[4:])
+	h.Flags = Flags(le.Uint16(b[6:]))
+	h.Size = le.Uint32(b[8:])
+	h.Version = le.Uint32(b[12:])
+	h.LoadAddr = le.Uint32(b[16:])
+	h.KeyID = b[20]
+	if h.HdrVer != HeaderVersion || h.Flags&^Signed != 0 {
+		return ErrHeader
+	}
+	for _, c := range b[21:HeaderSize] {
+		if c != 0 {
+			return ErrHeader
+		}
+	}
+	return nil
+}
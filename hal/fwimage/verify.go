@@ -0,0 +1,151 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fwimage
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/subtle"
+	"hash"
+	"io"
+)
+
+// NewHash returns a new SHA-256 hash for the size bytes long message or nil if
+// it cannot hash such a long message. It has the same signature as the New
+// method of the hal/sha256 driver, e.g.:
+//
+//	v := &fwimage.Verifier{Hash: sha0.Driver().New, Keys: keys}
+type NewHash func(size int) hash.Hash
+
+// Software is the NewHash function that uses the crypto/sha256 package.
+func Software(size int) hash.Hash {
+	return sha256.New()
+}
+
+// KeyStore is a small store of the Ed25519 public keys indexed by the KeyID
+// field of the header. A nil entry represents a revoked key.
+type KeyStore []ed25519.PublicKey
+
+// Key returns the public key with the given id or nil if there is no such key.
+func (ks KeyStore) Key(id uint8) ed25519.PublicKey {
+	if int(id) < len(ks) && len(ks[id]) == ed25519.PublicKeySize {
+		return ks[id]
+	}
+	return nil
+}
+
+// Verifier verifies the firmware images.
+type Verifier struct {
+	Hash          NewHash  // hash function, nil means Software
+	Keys          KeyStore // trusted keys
+	RequireSigned bool     // reject images without signature
+	MinVersion    uint32   // reject images with lower Version
+	MaxSize       int64    // reject larger images if not zero
+}
+
+// bufLen is the length of the buffer used to read the image. It is a
+// multiple of the SHA-256 block size.
+const bufLen = 4096
+
+// readAt reads len(b) bytes at offset off. It returns io.ErrUnexpectedEOF if
+// the image is truncated.
+func readAt(r io.ReaderAt, b []byte, off int64) error {
+	n, err := r.ReadAt(b, off)
+	if n == len(b) {
+		return nil
+	}
+	if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// Verify reads the image at offset off in r (e.g. xip.Flash) and checks its
+// header, digest and signature. It returns the image header if the image can
+// be trusted.
+func (v *Verifier) Verify(r io.ReaderAt, off int64) (*Header, error) {
+	buf := make([]byte, bufLen)
+	hb := buf[:HeaderSize]
+	if err := readAt(r, hb, off); err != nil {
+		return nil, err
+	}
+	h := new(Header)
+	if err := h.UnmarshalBinary(hb); err != nil {
+		return nil, err
+	}
+	if v.MaxSize != 0 && h.ImageSize() > v.MaxSize {
+		return nil, ErrSize
+	}
+	if v.RequireSigned && h.Flags&Signed == 0 {
+		return nil, ErrUnsigned
+	}
+	if h.Version < v.MinVersion {
+		return nil, ErrRollback
+	}
+	var pub ed25519.PublicKey
+	if h.Flags&Signed != 0 {
+		if pub = v.Keys.Key(h.KeyID); pub == nil {
+			return nil, ErrKey
+		}
+	}
+	newHash := v.Hash
+	if newHash == nil {
+		newHash = Software
+	}
+	n := HeaderSize + int64(h.Size)
+	md := newHash(int(n))
+	if md == nil {
+		return nil, ErrSize
+	}
+	// Reset releases the hardware engine if Verify returns before Sum.
+	defer md.Reset()
+	for k := int64(0); k < n; {
+		b := buf
+		if rem := n - k; rem < int64(len(b)) {
+			b = b[:rem]
+		}
+		if err := readAt(r, b, off+k); err != nil {
+			return nil, err
+		}
+		md.Write(b)
+		k += int64(len(b))
+	}
+	var digest [DigestSize]byte
+	md.Sum(digest[:0])
+	tr := buf[:h.TrailerSize()]
+	if err := readAt(r, tr, off+n); err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(digest[:], tr[:DigestSize]) != 1 {
+		return nil, ErrDigest
+	}
+	if pub != nil && !ed25519.Verify(pub, digest[:], tr[DigestSize:]) {
+		return nil, ErrSignature
+	}
+	return h, nil
+}
+
+// Seal returns the complete image that consists of the header h, the payload
+// and the trailer. It sets the Magic, HdrVer, Size and Flags fields of h. The
+// image is signed if key is not nil. Seal is intended for the host tools and
+// tests.
+func Seal(h *Header, payload []byte, key ed25519.PrivateKey) []byte {
+	h.Magic = Magic
+	h.HdrVer = HeaderVersion
+	h.Size = uint32(len(payload))
+	h.Flags &^= Signed
+	if key != nil {
+		h.Flags |= Signed
+	}
+	img := make([]byte, h.ImageSize())
+	h.put(img)
+	n := HeaderSize + copy(img[HeaderSize:], payload)
+	digest := sha256.Sum256(img[:n])
+	copy(img[n:], digest[:])
+	if key != nil {
+		copy(img[n+DigestSize:], ed25519.Sign(key, digest[:]))
+	}
+	return img
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i2c
+
+import "github.com/embeddedgo/kendryte/hal/internal"
+
+// Bus is the interface to the I2C bus used by the most device drivers. The
+// addr is a 7-bit address or a 10-bit address with the A10 flag set.
+type Bus interface {
+	Tx(addr uint16, w, r []byte) error
+}
+
+// Conn is the interface to the I2C device with the known address.
+type Conn interface {
+	Tx(w, r []byte) error
+}
+
+// Lock locks the bus (the mutex is common to all drivers of the same
+// peripheral). Use it to perform a sequence of transactions (Write,
+// Read, WriteRead) that must not be interleaved with the transactions
+// performed by other goroutines.
+func (d *Driver) Lock() {
+	internal.MX.I2C[d.p.n()].Lock()
+}
+
+// Unlock unlocks the bus.
+func (d *Driver) Unlock() {
+	internal.MX.I2C[d.p.n()].Unlock()
+}
+
+// Tx implements Bus interface. It locks the bus for the time of transaction
+// so the driver can be shared by multiple goroutines, also running on
+// different harts.
+func (d *Driver) Tx(addr uint16, w, r []byte) error {
+	internal.MX.I2C[d.p.n()].Lock()
+	err := d.WriteRead(Addr(addr), w, r)
+	internal.MX.I2C[d.p.n()].Unlock()
+	return err
+}
+
+// Device represents the I2C device connected to the Bus. It implements Conn
+// interface.
+type Device struct {
+	Bus  Bus
+	Addr Addr
+}
+
+// NewDevice returns the handle to the device with the address a on the bus b.
+func NewDevice(b Bus, a Addr) *Device {
+	return &Device{b, a}
+}
+
+// Tx implements Conn interface.
+func (d *Device) Tx(w, r []byte) error {
+	return d.Bus.Tx(uint16(d.Addr), w, r)
+}
+
+// Write writes w to the device.
+func (d *Device) Write(w []byte) error {
+	return d.Bus.Tx(uint16(d.Addr), w, nil)
+}
+
+// Read reads len(r) bytes from the device.
+func (d *Device) Read(r []byte) error {
+	return d.Bus.Tx(uint16(d.Addr), nil, r)
+}
+
+// ReadReg reads len(r) bytes starting from the register reg of the device
+// that uses the 8-bit register addresses.
+func (d *Device) ReadReg(reg byte, r []byte) error {
+	return d.Bus.Tx(uint16(d.Addr), []byte{reg}, r)
+}
+
+// WriteReg writes b to the register reg of the device that uses the 8-bit
+// register addresses.
+func (d *Device) WriteReg(reg, b byte) error {
+	return d.Bus.Tx(uint16(d.Addr), []byte{reg, b}, nil)
+}
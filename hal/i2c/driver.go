@@ -0,0 +1,255 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i2c
+
+import (
+	"embedded/rtos"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+type DriverError uint8
+
+const (
+	// ErrAddrNACK is returned if the slave has not acknowledged its address.
+	ErrAddrNACK DriverError = iota + 1
+
+	// ErrDataNACK is returned if the slave has not acknowledged the data
+	// byte.
+	ErrDataNACK
+
+	// ErrArbLost is returned if the master has lost the bus arbitration.
+	ErrArbLost
+
+	// ErrAbort is returned if the transfer has been aborted for other reason
+	// (see Periph.AbortSource).
+	ErrAbort
+
+	// ErrTimeout is returned if timeout occured. The transfer has been
+	// aborted and you can not determine how many bytes have been transferred.
+	ErrTimeout
+)
+
+// Error implements error interface.
+func (e DriverError) Error() string {
+	switch e {
+	case ErrAddrNACK:
+		return "i2c: address NACK"
+	case ErrDataNACK:
+		return "i2c: data NACK"
+	case ErrArbLost:
+		return "i2c: arbitration lost"
+	case ErrAbort:
+		return "i2c: transfer aborted"
+	case ErrTimeout:
+		return "i2c: timeout"
+	}
+	return ""
+}
+
+func abortError(src uint32) DriverError {
+	switch {
+	case src&(1<<0|1<<1|1<<2) != 0:
+		return ErrAddrNACK
+	case src&(1<<3) != 0:
+		return ErrDataNACK
+	case src&(1<<12) != 0:
+		return ErrArbLost
+	}
+	return ErrAbort
+}
+
+// Driver is an interrupt based driver for the I2C peripheral. It works in the
+// master mode (see Setup) or in the slave mode (see SetupSlave). In the master
+// mode it supports one goroutine at a time.
+type Driver struct {
+	p    *Periph
+	addr Addr
+
+	w    []byte
+	r    []byte
+	ncmd int // number of commands written to the Tx FIFO
+	nr   int // number of bytes read from the Rx FIFO
+	err  uint32
+
+	// slave state
+	rm    RegMap
+	sev   chan<- SlaveEvent
+	ev    SlaveEvent
+	reg   byte
+	first bool
+
+	isr     uint32
+	done    rtos.Note
+	timeout time.Duration
+}
+
+// NewDriver returns a new driver for p.
+func NewDriver(p *Periph) *Driver {
+	return &Driver{p: p, addr: ^Addr(0), timeout: -1}
+}
+
+func (d *Driver) Periph() *Periph {
+	return d.p
+}
+
+// Common I2C speeds.
+const (
+	Std100k  = 100e3  // standard mode
+	Fast400k = 400e3  // fast mode
+	Fast1M   = 1000e3 // fast mode plus
+)
+
+// Setup enables clock and resets the peripheral, configures it as the master
+// and sets the SCL frequency to a value close to but not greater than speed
+// (see Periph.SetSpeed). It returns the configured SCL frequency.
+func (d *Driver) Setup(speed int) int {
+	p := d.p
+	p.EnableClock()
+	p.Reset()
+	p.Disable()
+	p.SetIRQ(0)
+	p.SetConf(Master | Std | Restart | NoSlave)
+	speed = p.SetSpeed(speed)
+	p.rx_tl.Store(0)
+	p.tx_tl.Store(FIFOLen / 2)
+	d.addr = ^Addr(0)
+	d.rm, d.sev = nil, nil
+	return speed
+}
+
+// SetTimeout sets the timeout used by all transfer methods.
+func (d *Driver) SetTimeout(timeout time.Duration) {
+	d.timeout = timeout
+}
+
+// ISR handles I2C interrupts.
+func (d *Driver) ISR() {
+	atomic.StoreUint32(&d.isr, 1)
+	if d.rm != nil {
+		d.slaveISR()
+		atomic.StoreUint32(&d.isr, 0)
+		return
+	}
+	p := d.p
+	ev := Event(p.intr_stat.Load())
+	if ev&TxAbort != 0 {
+		src := p.tx_abrt_source.Load()
+		p.clr_tx_abrt.Load()
+		atomic.StoreUint32(&d.err, uint32(abortError(src)))
+		p.intr_mask.Store(0)
+		d.done.Wakeup()
+		atomic.StoreUint32(&d.isr, 0)
+		return
+	}
+	// Read the received data.
+	for m := int(p.rxflr.Load()); m > 0; m-- {
+		b := byte(p.data_cmd.Load())
+		if d.nr < len(d.r) {
+			d.r[d.nr] = b
+			d.nr++
+		}
+	}
+	if ev&StopDet != 0 {
+		p.clr_stop_det.Load()
+		if d.ncmd < len(d.w)+len(d.r) || d.nr < len(d.r) {
+			// premature STOP, the Tx FIFO became empty
+			atomic.StoreUint32(&d.err, uint32(ErrAbort))
+		}
+		p.intr_mask.Store(0)
+		d.done.Wakeup()
+		atomic.StoreUint32(&d.isr, 0)
+		return
+	}
+	// Write commands. The Tx FIFO must not become empty before the last
+	// command because the peripheral generates STOP in such case.
+	n := len(d.w) + len(d.r)
+	m := FIFOLen - int(p.txflr.Load())
+	blocked := false
+	for ; m > 0 && d.ncmd < n; m-- {
+		if d.ncmd < len(d.w) {
+			p.data_cmd.Store(uint32(d.w[d.ncmd]))
+		} else {
+			// Limit the outstanding reads to the Rx FIFO size. The RxHigh
+			// interrupt will resume writing.
+			if d.ncmd-len(d.w)-d.nr >= FIFOLen {
+				blocked = true
+				break
+			}
+			p.data_cmd.Store(1 << 8)
+		}
+		d.ncmd++
+	}
+	if d.ncmd == n || blocked {
+		p.intr_mask.ClearBits(uint32(TxLow))
+	} else {
+		p.intr_mask.SetBits(uint32(TxLow))
+	}
+	atomic.StoreUint32(&d.isr, 0)
+}
+
+// WriteRead writes w to and next reads len(r) bytes from the slave with the
+// address a. It generates repeated START between the write and the read phase
+// and STOP at the end of the transaction.
+func (d *Driver) WriteRead(a Addr, w, r []byte) error {
+	if len(w)+len(r) == 0 {
+		return nil
+	}
+	p := d.p
+	if a != d.addr {
+		p.Disable()
+		p.SetTarget(a)
+		d.addr = a
+	}
+	p.Enable()
+	d.w, d.r = w, r
+	d.ncmd, d.nr = 0, 0
+	d.err = 0
+	p.clr_intr.Load()
+	d.done.Clear()
+	ev := TxLow | TxAbort | StopDet
+	if len(r) != 0 {
+		ev |= RxHigh
+	}
+	p.intr_mask.Store(uint32(ev)) // TxLow starts the transaction
+	var err error
+	if !d.done.Sleep(d.timeout) {
+		p.intr_mask.Store(0)
+		for atomic.LoadUint32(&d.isr) != 0 {
+			runtime.Gosched()
+		}
+		p.Abort(stuckTimeout)
+		p.clr_intr.Load()
+		err = ErrTimeout
+	} else if e := atomic.LoadUint32(&d.err); e != 0 {
+		err = DriverError(e)
+	}
+	start := time.Now()
+	for p.Status()&MstActive != 0 {
+		if time.Since(start) > stuckTimeout {
+			// The bus is stuck. Don't wait for the controller.
+			err = ErrTimeout
+			break
+		}
+		runtime.Gosched()
+	}
+	d.w, d.r = nil, nil
+	return err
+}
+
+// stuckTimeout limits the time WriteRead waits for the end of the abort
+// procedure and for the idle master state.
+const stuckTimeout = 10 * time.Millisecond
+
+// Write writes w to the slave with the address a.
+func (d *Driver) Write(a Addr, w []byte) error {
+	return d.WriteRead(a, w, nil)
+}
+
+// Read reads len(r) bytes from the slave with the address a.
+func (d *Driver) Read(a Addr, r []byte) error {
+	return d.WriteRead(a, nil, r)
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i2c1
+
+import (
+	_ "unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/i2c"
+	"github.com/embeddedgo/kendryte/hal/i2c/internal"
+)
+
+var driver *i2c.Driver
+
+// Driver returns a ready to use driver for I2C1 peripheral.
+func Driver() *i2c.Driver {
+	if driver == nil {
+		driver = internal.I2C(1)
+	}
+	return driver
+}
+
+//go:interrupthandler
+func _I2C1_Handler() { driver.ISR() }
+
+//go:linkname _I2C1_Handler IRQ9_Handler
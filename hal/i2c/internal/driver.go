@@ -0,0 +1,24 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"embedded/rtos"
+
+	"github.com/embeddedgo/kendryte/hal/i2c"
+	"github.com/embeddedgo/kendryte/hal/irq"
+)
+
+// I2C returns a ready to use driver for I2Cn peripheral.
+func I2C(n int) *i2c.Driver {
+	driver := i2c.NewDriver(i2c.I2C(n)) // must before ir.Enable
+	ctx := irq.M0
+	ir := irq.I2C0 + rtos.IRQ(n)
+	if ir&1 != 0 {
+		ctx = irq.M1
+	}
+	ir.Enable(rtos.IntPrioLow, ctx)
+	return driver
+}
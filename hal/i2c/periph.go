@@ -0,0 +1,335 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package i2c provides interface to the I2C peripherals.
+package i2c
+
+import (
+	"embedded/mmio"
+	"runtime"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/bus"
+	"github.com/embeddedgo/kendryte/p/mmap"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// Synopsys DW_apb_i2c
+//
+//  K210 I2C features
+//  -----------------
+//	FIFO depth                8 bytes
+//	EMPTYFIFO_HOLD_MASTER_EN  no (STOP is generated when Tx FIFO becomes empty)
+//	speed modes               standard (SS counters used for all speeds)
+
+// Periph represents I2C peripheral.
+type Periph struct {
+	con                mmio.U32
+	tar                mmio.U32
+	sar                mmio.U32
+	_                  uint32
+	data_cmd           mmio.U32
+	ss_scl_hcnt        mmio.U32
+	ss_scl_lcnt        mmio.U32
+	_                  [4]uint32
+	intr_stat          mmio.U32
+	intr_mask          mmio.U32
+	raw_intr_stat      mmio.U32
+	rx_tl              mmio.U32
+	tx_tl              mmio.U32
+	clr_intr           mmio.U32
+	clr_rx_under       mmio.U32
+	clr_rx_over        mmio.U32
+	clr_tx_over        mmio.U32
+	clr_rd_req         mmio.U32
+	clr_tx_abrt        mmio.U32
+	clr_rx_done        mmio.U32
+	clr_activity       mmio.U32
+	clr_stop_det       mmio.U32
+	clr_start_det      mmio.U32
+	clr_gen_call       mmio.U32
+	enable             mmio.U32
+	status             mmio.U32
+	txflr              mmio.U32
+	rxflr              mmio.U32
+	sda_hold           mmio.U32
+	tx_abrt_source     mmio.U32
+	slv_data_nack_only mmio.U32
+	dma_cr             mmio.U32
+	dma_tdlr           mmio.U32
+	dma_rdlr           mmio.U32
+	sda_setup          mmio.U32
+	general_call       mmio.U32
+	enable_status      mmio.U32
+	fs_spklen          mmio.U32
+	_                  [20]uint32
+	comp_param_1       mmio.U32
+	comp_version       mmio.U32
+	comp_type          mmio.U32
+}
+
+// FIFOLen is the depth of the Tx and Rx FIFOs.
+const FIFOLen = 8
+
+// I2C returns n-th I2C peripheral (n = 0, 1, 2).
+func I2C(n int) *Periph {
+	if uint(n) > 2 {
+		panic("i2c: bad number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.I2C0_BASE + uintptr(n)*0x10000))
+}
+
+func (p *Periph) Bus() bus.Bus {
+	return bus.APB0
+}
+
+// n returns I2C number.
+func (p *Periph) n() uint {
+	return uint((uintptr(unsafe.Pointer(p)) - mmap.I2C0_BASE) / 0x10000)
+}
+
+func (p *Periph) EnableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_CENT.Lock()
+	if mx.APB0_CLK_EN == 0 {
+		sc.APB0_CLK_EN().Set()
+	}
+	mx.APB0_CLK_EN++
+	mx.CLK_EN_CENT.Unlock()
+
+	mx.CLK_EN_PERI.Lock()
+	sc.CLK_EN_PERI.SetBits(sysctl.I2C0_CLK_EN << p.n())
+	mx.CLK_EN_PERI.Unlock()
+}
+
+func (p *Periph) DisableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_PERI.Lock()
+	sc.CLK_EN_PERI.ClearBits(sysctl.I2C0_CLK_EN << p.n())
+	mx.CLK_EN_PERI.Unlock()
+
+	mx.CLK_EN_CENT.Lock()
+	mx.APB0_CLK_EN--
+	if mx.APB0_CLK_EN == 0 {
+		sc.APB0_CLK_EN().Clear()
+	}
+	mx.CLK_EN_CENT.Unlock()
+}
+
+func (p *Periph) Reset() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.PERI_RESET.Lock()
+	sc.PERI_RESET.SetBits(sysctl.I2C0_RESET << p.n())
+	mx.PERI_RESET.Unlock()
+
+	time.Sleep(10 * time.Microsecond)
+
+	mx.PERI_RESET.Lock()
+	sc.PERI_RESET.ClearBits(sysctl.I2C0_RESET << p.n())
+	mx.PERI_RESET.Unlock()
+}
+
+// Clock returns the frequency of the peripheral clock (ic_clk) in Hz. It is
+// derived from PLL0 and divided by the CLK_TH5 threshold (it is not the APB0
+// clock).
+func (p *Periph) Clock() int64 {
+	sc := sysctl.SYSCTL()
+	th := int64(sc.CLK_TH5.LoadBits(sysctl.I2C0_CLK<<(p.n()*8))>>(sysctl.I2C0_CLKn+p.n()*8)) + 1
+	return internal.PLLClock(0) / (th * 2)
+}
+
+// Enable enables the peripheral.
+func (p *Periph) Enable() {
+	p.enable.Store(1)
+}
+
+// Disable disables the peripheral and waits until it is disabled (the
+// transfer in progress is completed first).
+func (p *Periph) Disable() {
+	p.enable.Store(0)
+	for p.enable_status.Load()&1 != 0 {
+		runtime.Gosched()
+	}
+}
+
+// Abort aborts the master transfer in progress. The peripheral generates STOP
+// and flushes the Tx FIFO. Abort waits at most timeout for the end of abort
+// procedure and reports whether it has ended (it may never end if the bus is
+// stuck, e.g. SCL is held low by a slave).
+func (p *Periph) Abort(timeout time.Duration) bool {
+	p.enable.SetBits(1 << 1)
+	start := time.Now()
+	for p.enable.Load()&(1<<1) != 0 {
+		if time.Since(start) > timeout {
+			return false
+		}
+		runtime.Gosched()
+	}
+	return true
+}
+
+// Conf represents the configuration of the peripheral.
+type Conf uint16
+
+const (
+	Master      Conf = 1 << 0 // master mode enabled
+	Std         Conf = 1 << 1 // standard speed mode
+	Slave10     Conf = 1 << 3 // slave responds to 10-bit address
+	Restart     Conf = 1 << 5 // master can generate repeated START
+	NoSlave     Conf = 1 << 6 // slave mode disabled
+	StopIfAddr  Conf = 1 << 7 // slave generates StopDet only if addressed
+	TxEmptyCtrl Conf = 1 << 8 // TxEmpty only after the last byte is sent
+)
+
+// Conf returns the current configuration.
+func (p *Periph) Conf() Conf {
+	return Conf(p.con.Load())
+}
+
+// SetConf sets the configuration. The peripheral must be disabled.
+func (p *Periph) SetConf(cfg Conf) {
+	p.con.Store(uint32(cfg))
+}
+
+// SetSpeed configures the SCL frequency to the value close to but not greater
+// than speed (Hz). The SCL high and low times are calculated to meet the
+// requirements of standard (up to 100 kHz), fast (up to 400 kHz) and fast mode
+// plus (up to 1 MHz) modes. The peripheral must be disabled. SetSpeed returns
+// the SCL frequency that will be generated, assuming the zero rise time.
+func (p *Periph) SetSpeed(speed int) int {
+	clk := p.Clock()
+	period := (clk + int64(speed) - 1) / int64(speed)
+	var lcnt int64
+	if speed <= 100e3 {
+		lcnt = period * 54 / 100 // tLOW >= 4.7 µs, tHIGH >= 4 µs
+	} else {
+		lcnt = period * 2 / 3 // tLOW >= 1.3 µs, tHIGH >= 0.6 µs (FM)
+	}
+	hcnt := period - lcnt
+	// The SCL high time is extended by the peripheral by spklen+7 ic_clk
+	// cycles and the low time by 1 cycle.
+	spk := int64(p.fs_spklen.Load() & 0xFF)
+	hcnt -= spk + 7
+	lcnt--
+	if hcnt < 6 {
+		hcnt = 6
+	} else if hcnt > 0xFFFF {
+		hcnt = 0xFFFF
+	}
+	if lcnt < 8 {
+		lcnt = 8
+	} else if lcnt > 0xFFFF {
+		lcnt = 0xFFFF
+	}
+	p.ss_scl_hcnt.Store(uint32(hcnt))
+	p.ss_scl_lcnt.Store(uint32(lcnt))
+	return int(clk / (hcnt + spk + 7 + lcnt + 1))
+}
+
+// Addr represents the I2C slave address. The 7-bit addresses are used as is.
+// Use A10 flag to mark the 10-bit address.
+type Addr uint16
+
+const A10 Addr = 1 << 15 // 10-bit address
+
+// SetTarget sets the address of the slave accessed by master. The peripheral
+// must be disabled.
+func (p *Periph) SetTarget(a Addr) {
+	tar := uint32(a & 0x3FF)
+	if a&A10 != 0 {
+		tar |= 1 << 12
+	}
+	p.tar.Store(tar)
+}
+
+// SetAddr sets the slave address of the peripheral. The peripheral must be
+// disabled. Use Slave10 configuration flag for the 10-bit address.
+func (p *Periph) SetAddr(a Addr) {
+	p.sar.Store(uint32(a & 0x3FF))
+}
+
+// SetGenCallACK enables or disables acknowledging of the general call address
+// in the slave mode.
+func (p *Periph) SetGenCallACK(en bool) {
+	var v uint32
+	if en {
+		v = 1
+	}
+	p.general_call.Store(v)
+}
+
+type Status uint8
+
+const (
+	Active     Status = 1 << 0 // activity
+	TxNotFull  Status = 1 << 1 // Tx FIFO is not full
+	TxEmpty    Status = 1 << 2 // Tx FIFO is empty
+	RxNotEmpty Status = 1 << 3 // Rx FIFO is not empty
+	RxFull     Status = 1 << 4 // Rx FIFO is full
+	MstActive  Status = 1 << 5 // master FSM is not idle
+	SlvActive  Status = 1 << 6 // slave FSM is not idle
+)
+
+func (p *Periph) Status() Status {
+	return Status(p.status.Load())
+}
+
+// Event represents the interrupt events.
+type Event uint16
+
+const (
+	RxUnderflow Event = 1 << 0  // read from the empty Rx FIFO
+	RxOverflow  Event = 1 << 1  // Rx FIFO overflow, received data lost
+	RxHigh      Event = 1 << 2  // Rx FIFO level is above threshold
+	TxOverflow  Event = 1 << 3  // write to the full Tx FIFO
+	TxLow       Event = 1 << 4  // Tx FIFO level is at or below threshold
+	ReadReq     Event = 1 << 5  // slave: master attempts to read data
+	TxAbort     Event = 1 << 6  // transmit aborted (see AbortSource)
+	RxDone      Event = 1 << 7  // slave: master NACKed the last byte
+	Activity    Event = 1 << 8  // I2C activity
+	StopDet     Event = 1 << 9  // STOP condition occured
+	StartDet    Event = 1 << 10 // START or RESTART condition occured
+	GenCall     Event = 1 << 11 // general call address received
+
+	EvAll Event = 1<<12 - 1
+)
+
+// Events returns the pending events that are enabled to generate interrupt
+// request.
+func (p *Periph) Events() Event {
+	return Event(p.intr_stat.Load())
+}
+
+// RawEvents returns all pending events.
+func (p *Periph) RawEvents() Event {
+	return Event(p.raw_intr_stat.Load())
+}
+
+// Clear clears all events that can be cleared by software.
+func (p *Periph) Clear() {
+	p.clr_intr.Load()
+}
+
+// IRQEnabled returns events that are enabled to generate interrupt request.
+func (p *Periph) IRQEnabled() Event {
+	return Event(p.intr_mask.Load())
+}
+
+// SetIRQ sets the events that are enabled to generate interrupt request.
+func (p *Periph) SetIRQ(ev Event) {
+	p.intr_mask.Store(uint32(ev))
+}
+
+// AbortSource returns the source of the last TxAbort event.
+func (p *Periph) AbortSource() uint32 {
+	return p.tx_abrt_source.Load()
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i2c
+
+// Slave mode
+//
+// In the slave mode the driver emulates a typical I2C device with 8-bit
+// register addresses. The first byte written by the master after START is the
+// register address. The following written bytes are stored in the subsequent
+// registers. The master reads the subsequent registers starting from the last
+// set register address (usually set using the write of the address followed
+// by repeated START). The register address is auto-incremented after every
+// byte read or written.
+//
+// The peripheral stretches the SCL clock if the master reads data and the Tx
+// FIFO is empty so the ISR has time to respond to the ReadReq event.
+
+// RegMap represents the register map served by the driver in the slave mode.
+// Its methods are called by the interrupt handler so they must be short and
+// must not block or allocate memory.
+type RegMap interface {
+	// ReadReg returns the content of the register addr.
+	ReadReg(addr byte) byte
+
+	// WriteReg writes b to the register addr.
+	WriteReg(addr, b byte)
+}
+
+// Regs implements RegMap using a byte slice. Reading a non-existent register
+// returns 0xFF, writing it is ignored.
+type Regs []byte
+
+func (r Regs) ReadReg(addr byte) byte {
+	if int(addr) < len(r) {
+		return r[addr]
+	}
+	return 0xFF
+}
+
+func (r Regs) WriteReg(addr, b byte) {
+	if int(addr) < len(r) {
+		r[addr] = b
+	}
+}
+
+// SlaveEvent describes the slave transaction.
+type SlaveEvent struct {
+	Reg     byte // first accessed register or first byte of the general call
+	N       int  // number of bytes read or written
+	Write   bool // the master wrote data
+	GenCall bool // general call, the data were not written to the registers
+}
+
+// SetupSlave enables clock and resets the peripheral, configures it as the
+// slave with the address a and starts serving the register map rm. If genCall
+// is true the general call address is acknowledged. The completed
+// transactions are reported to the ev channel if it is not nil. The ISR never
+// blocks on ev so the events are dropped if the channel is not ready.
+func (d *Driver) SetupSlave(a Addr, rm RegMap, genCall bool, ev chan<- SlaveEvent) {
+	if rm == nil {
+		panic("i2c: nil register map")
+	}
+	p := d.p
+	p.EnableClock()
+	p.Reset()
+	p.Disable()
+	p.SetIRQ(0)
+	cfg := Std | StopIfAddr
+	if a&A10 != 0 {
+		cfg |= Slave10
+	}
+	p.SetConf(cfg)
+	p.SetAddr(a)
+	p.SetGenCallACK(genCall)
+	p.rx_tl.Store(0)
+	p.tx_tl.Store(0)
+	d.addr = ^Addr(0)
+	d.rm, d.sev = rm, ev
+	d.ev = SlaveEvent{}
+	d.first = true
+	p.Clear()
+	p.Enable()
+	p.SetIRQ(RxHigh | ReadReq | TxAbort | RxDone | StopDet | StartDet | GenCall)
+}
+
+func (d *Driver) slaveISR() {
+	p := d.p
+	ev := Event(p.intr_stat.Load())
+	// The bytes in the Rx FIFO may belong to the transaction that precedes
+	// the detected START or general call.
+	d.readRx()
+	if ev&StartDet != 0 {
+		// START or repeated START, the next written byte is the register
+		// address.
+		p.clr_start_det.Load()
+		d.sendEvent()
+		d.first = true
+	}
+	if ev&GenCall != 0 {
+		p.clr_gen_call.Load()
+		d.sendEvent()
+		d.ev.GenCall = true
+		d.ev.Write = true
+		d.first = false
+	}
+	d.readRx()
+	if ev&ReadReq != 0 {
+		if d.ev.N == 0 || d.ev.Write {
+			d.sendEvent()
+			d.ev.Reg = d.reg
+		}
+		p.data_cmd.Store(uint32(d.rm.ReadReg(d.reg)))
+		p.clr_rd_req.Load()
+		d.reg++
+		d.ev.N++
+		d.first = false
+	}
+	if ev&TxAbort != 0 {
+		p.clr_tx_abrt.Load() // the Tx FIFO was flushed at the end of read
+	}
+	if ev&RxDone != 0 {
+		p.clr_rx_done.Load() // the master NACKed the last read byte
+	}
+	if ev&StopDet != 0 {
+		p.clr_stop_det.Load()
+		d.sendEvent()
+		d.first = true
+	}
+}
+
+// readRx handles the bytes in the Rx FIFO.
+func (d *Driver) readRx() {
+	p := d.p
+	for m := int(p.rxflr.Load()); m > 0; m-- {
+		b := byte(p.data_cmd.Load())
+		switch {
+		case d.ev.GenCall:
+			if d.ev.N == 0 {
+				d.ev.Reg = b
+			}
+		case d.first:
+			d.reg = b
+			d.first = false
+			continue
+		default:
+			if d.ev.N == 0 || !d.ev.Write {
+				d.sendEvent()
+				d.ev.Reg, d.ev.Write = d.reg, true
+			}
+			d.rm.WriteReg(d.reg, b)
+			d.reg++
+		}
+		d.ev.N++
+	}
+}
+
+// sendEvent reports the current transaction, if any, and resets its state.
+func (d *Driver) sendEvent() {
+	if d.ev.N != 0 && d.sev != nil {
+		select {
+		case d.sev <- d.ev:
+		default:
+		}
+	}
+	d.ev = SlaveEvent{}
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i2c
+
+import "github.com/embeddedgo/kendryte/hal/fpioa"
+
+type Signal uint8
+
+const (
+	SCL Signal = iota
+	SDA
+)
+
+// UsePin is a helper function that can be used to configure FPIOA pins as
+// required by I2C peripheral. The pins are configured as bidirectional with
+// pull-up enabled. The internal pull-up is weak so in most cases the external
+// pull-up resistors are still required.
+func (d *Driver) UsePin(pin fpioa.Pin, sig Signal) {
+	cfg := fpioa.I2C0_SCLK + fpioa.Config(d.p.n()*2) + fpioa.Config(sig)
+	cfg |= fpioa.DriveH34L23 | fpioa.EnOE | fpioa.EnIE | fpioa.Schmitt | fpioa.PullUp
+	pin.Setup(cfg)
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package audio
+
+import (
+	"bufio"
+	"io"
+	"sync/atomic"
+
+	"github.com/embeddedgo/kendryte/hal/i2s"
+)
+
+// MaxRateRatio is the maximum ratio between the stream and the output sample
+// rates (or vice versa) that can be handled by the resampler.
+const MaxRateRatio = 8
+
+// Stats contains the playback statistics.
+type Stats struct {
+	Frames    int64 // number of played frames (at the output sample rate)
+	Underruns int   // number of buffers sent more than once (see i2s.ErrOverrun)
+}
+
+// Player plays the WAV streams using the I2S driver. It uses the channel 0
+// of the I2S peripheral in the stereo mode. Use Play from a dedicated
+// goroutine to keep the DMA ring full.
+type Player struct {
+	d       *i2s.Driver
+	rate    int
+	bits    uint
+	buf     [2][]int32
+	stop    uint32
+	frames  int64
+	underrs uint32
+}
+
+// NewPlayer returns a new player that uses d to play audio. The I2S
+// peripheral must be configured for the sample rate rate (see
+// i2s.Driver.Setup) and d must have the Tx DMA channel set. The bits is the
+// sample length supported by DAC (12, 16, 20, 24, 32). The bufLen is the
+// length of every of two DMA buffers in frames.
+func NewPlayer(d *i2s.Driver, rate, bits, bufLen int) *Player {
+	p := &Player{d: d, rate: rate, bits: uint(bits)}
+	p.buf[0] = make([]int32, bufLen*2)
+	p.buf[1] = make([]int32, bufLen*2)
+	return p
+}
+
+// Stats returns the playback statistics of the last (or current) Play.
+func (p *Player) Stats() Stats {
+	return Stats{
+		Frames:    atomic.LoadInt64(&p.frames),
+		Underruns: int(atomic.LoadUint32(&p.underrs)),
+	}
+}
+
+// Stop stops the current playback. It can be called from any goroutine.
+func (p *Player) Stop() {
+	atomic.StoreUint32(&p.stop, 1)
+}
+
+// Play plays the WAV stream read from r. It returns after the whole stream
+// has been played, the playback has been stopped (see Stop) or an error
+// occured.
+func (p *Player) Play(r io.Reader) error {
+	f, n, err := ReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if f.SampleRate > p.rate*MaxRateRatio || f.SampleRate*MaxRateRatio < p.rate {
+		return ErrRate
+	}
+	if n >= 0 {
+		r = io.LimitReader(r, n)
+	}
+	src := &decoder{r: bufio.NewReaderSize(r, 512), f: f}
+	rs := newResampler(src, f.SampleRate, p.rate)
+	atomic.StoreUint32(&p.stop, 0)
+	atomic.StoreInt64(&p.frames, 0)
+	atomic.StoreUint32(&p.underrs, 0)
+
+	tail := 0 // number of silent buffers after the end of stream
+	if !p.fill(rs, p.buf[0]) {
+		tail++
+	}
+	if tail != 0 || !p.fill(rs, p.buf[1]) {
+		tail++
+	}
+	if err = p.d.StartTx(p.buf[0], p.buf[1]); err != nil {
+		return err
+	}
+	for tail < 3 {
+		buf, err := p.d.Tx()
+		switch err {
+		case nil:
+		case i2s.ErrOverrun:
+			atomic.AddUint32(&p.underrs, 1)
+		default:
+			p.d.StopTx()
+			return err
+		}
+		atomic.AddInt64(&p.frames, int64(len(buf)/2))
+		if atomic.LoadUint32(&p.stop) != 0 {
+			break
+		}
+		if tail != 0 || !p.fill(rs, buf) {
+			tail++
+		}
+	}
+	p.d.StopTx()
+	return src.err
+}
+
+// fill fills buf with the next frames. It fills the remaining part of buf
+// with silence and returns false at the end of stream.
+func (p *Player) fill(rs *resampler, buf []int32) bool {
+	shift := 32 - p.bits
+	for i := 0; i < len(buf); i += 2 {
+		l, r, ok := rs.next()
+		if !ok {
+			for ; i < len(buf); i++ {
+				buf[i] = 0
+			}
+			return false
+		}
+		buf[i] = l >> shift
+		buf[i+1] = r >> shift
+	}
+	return true
+}
+
+// decoder decodes the PCM frames to the left aligned 32-bit samples.
+type decoder struct {
+	r   *bufio.Reader
+	f   Format
+	b   [6]byte
+	err error
+}
+
+func (dc *decoder) frame() (l, r int32, ok bool) {
+	b := dc.b[:dc.f.frameLen()]
+	if _, err := io.ReadFull(dc.r, b); err != nil {
+		if err != io.EOF && err != io.ErrUnexpectedEOF {
+			dc.err = err
+		}
+		return 0, 0, false
+	}
+	switch dc.f.Bits {
+	case 8:
+		l = int32(int8(b[0]-128)) << 24
+	case 16:
+		l = int32(b[0])<<16 | int32(b[1])<<24
+	case 24:
+		l = int32(b[0])<<8 | int32(b[1])<<16 | int32(b[2])<<24
+	}
+	if dc.f.Channels == 1 {
+		return l, l, true
+	}
+	b = b[dc.f.Bits/8:]
+	switch dc.f.Bits {
+	case 8:
+		r = int32(int8(b[0]-128)) << 24
+	case 16:
+		r = int32(b[0])<<16 | int32(b[1])<<24
+	case 24:
+		r = int32(b[0])<<8 | int32(b[1])<<16 | int32(b[2])<<24
+	}
+	return l, r, true
+}
+
+// resampler converts the sample rate using the linear interpolation.
+type resampler struct {
+	src    *decoder
+	step   uint32 // input samples per output sample (16.16 fixed-point)
+	phase  uint32 // position between l0 and l1 (16.16 fixed-point)
+	l0, r0 int32
+	l1, r1 int32
+	last   bool // l0, r0 is the last frame of the stream
+	end    bool
+}
+
+func newResampler(src *decoder, from, to int) *resampler {
+	rs := &resampler{src: src}
+	rs.step = uint32((int64(from)<<16 + int64(to)/2) / int64(to))
+	var ok bool
+	rs.l0, rs.r0, ok = src.frame()
+	if !ok {
+		rs.end = true
+		return rs
+	}
+	if rs.l1, rs.r1, ok = src.frame(); !ok {
+		rs.l1, rs.r1 = rs.l0, rs.r0
+		rs.last = true
+	}
+	return rs
+}
+
+func (rs *resampler) next() (l, r int32, ok bool) {
+	if rs.end {
+		return 0, 0, false
+	}
+	if rs.step == 1<<16 {
+		l, r = rs.l0, rs.r0
+	} else {
+		ph := int64(rs.phase)
+		l = int32(int64(rs.l0) + (int64(rs.l1)-int64(rs.l0))*ph>>16)
+		r = int32(int64(rs.r0) + (int64(rs.r1)-int64(rs.r0))*ph>>16)
+	}
+	for rs.phase += rs.step; rs.phase >= 1<<16; rs.phase -= 1 << 16 {
+		if rs.last {
+			rs.end = true // the last frame has been passed
+			break
+		}
+		var ok bool
+		rs.l0, rs.r0 = rs.l1, rs.r1
+		if rs.l1, rs.r1, ok = rs.src.frame(); !ok {
+			// Hold the last frame so it is still returned.
+			rs.l1, rs.r1 = rs.l0, rs.r0
+			rs.last = true
+		}
+	}
+	return l, r, true
+}
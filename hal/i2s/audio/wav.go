@@ -0,0 +1,139 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package audio provides the audio playback on top of the I2S driver.
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+type Error uint8
+
+const (
+	// ErrFormat is returned if the stream is not a valid WAV file.
+	ErrFormat Error = iota + 1
+
+	// ErrCodec is returned if the WAV file does not contain the 8, 16 or
+	// 24-bit PCM mono or stereo data.
+	ErrCodec
+
+	// ErrRate is returned if the sample rate of the stream is not supported
+	// (cannot be resampled to the output sample rate).
+	ErrRate
+)
+
+// Error implements error interface.
+func (e Error) Error() string {
+	switch e {
+	case ErrFormat:
+		return "audio: bad WAV format"
+	case ErrCodec:
+		return "audio: unsupported codec"
+	case ErrRate:
+		return "audio: unsupported sample rate"
+	}
+	return ""
+}
+
+// Format describes the PCM data.
+type Format struct {
+	SampleRate int // frames per second
+	Channels   int // 1 (mono) or 2 (stereo)
+	Bits       int // bits per sample: 8 (unsigned), 16 or 24 (signed)
+}
+
+// frameLen returns the number of bytes in one frame.
+func (f *Format) frameLen() int {
+	return f.Channels * (f.Bits / 8)
+}
+
+const (
+	wavePCM        = 1
+	waveExtensible = 0xFFFE
+)
+
+// subtypePCM is the KSDATAFORMAT_SUBTYPE_PCM GUID that identifies the PCM
+// data in the WAVE_FORMAT_EXTENSIBLE format.
+var subtypePCM = [16]byte{
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00,
+	0x80, 0x00, 0x00, 0xAA, 0x00, 0x38, 0x9B, 0x71,
+}
+
+// ReadHeader reads the WAV header from r up to the beginning of the PCM data.
+// It returns the format of the data and the data length in bytes (-1 if
+// unknown, as in case of streamed files).
+func ReadHeader(r io.Reader) (f Format, dataLen int64, err error) {
+	var hdr [12]byte
+	if _, err = io.ReadFull(r, hdr[:]); err != nil {
+		return f, 0, ErrFormat
+	}
+	if string(hdr[0:4]) != "RIFF" || string(hdr[8:12]) != "WAVE" {
+		return f, 0, ErrFormat
+	}
+	fmtOK := false
+	for {
+		var ch [8]byte
+		if _, err = io.ReadFull(r, ch[:]); err != nil {
+			return f, 0, ErrFormat
+		}
+		n := int64(binary.LittleEndian.Uint32(ch[4:]))
+		pad := n & 1 // chunks are word aligned
+		switch string(ch[0:4]) {
+		case "fmt ":
+			if n < 16 {
+				return f, 0, ErrFormat
+			}
+			var b [16]byte
+			if _, err = io.ReadFull(r, b[:]); err != nil {
+				return f, 0, ErrFormat
+			}
+			tag := binary.LittleEndian.Uint16(b[0:])
+			f.Channels = int(binary.LittleEndian.Uint16(b[2:]))
+			f.SampleRate = int(binary.LittleEndian.Uint32(b[4:]))
+			f.Bits = int(binary.LittleEndian.Uint16(b[14:]))
+			if tag != wavePCM && tag != waveExtensible {
+				return f, 0, ErrCodec
+			}
+			if f.Channels < 1 || f.Channels > 2 ||
+				f.Bits != 8 && f.Bits != 16 && f.Bits != 24 {
+				return f, 0, ErrCodec
+			}
+			if f.SampleRate <= 0 {
+				return f, 0, ErrFormat
+			}
+			n -= 16
+			if tag == waveExtensible {
+				// cbSize, wValidBitsPerSample, dwChannelMask, SubFormat
+				var x [24]byte
+				if n < int64(len(x)) {
+					return f, 0, ErrFormat
+				}
+				if _, err = io.ReadFull(r, x[:]); err != nil {
+					return f, 0, ErrFormat
+				}
+				if binary.LittleEndian.Uint16(x[0:]) < 22 {
+					return f, 0, ErrFormat
+				}
+				if [16]byte(x[8:]) != subtypePCM {
+					return f, 0, ErrCodec
+				}
+				n -= int64(len(x))
+			}
+			fmtOK = true
+		case "data":
+			if !fmtOK {
+				return f, 0, ErrFormat
+			}
+			if n == 0 || n == 0xFFFFFFFF {
+				n = -1
+			}
+			return f, n, nil
+		}
+		if _, err = io.CopyN(io.Discard, r, n+pad); err != nil {
+			return f, 0, ErrFormat
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i2s
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/dma"
+	"github.com/embeddedgo/kendryte/hal/internal"
+)
+
+type DriverError uint8
+
+const (
+	// ErrOverrun is returned by Rx if DMA has filled the buffer still owned
+	// by the caller (the received samples were lost) and by Tx if DMA has
+	// sent the buffer still owned by the caller (the old samples were sent
+	// again). The caller must call Rx/Tx again within one buffer period.
+	ErrOverrun DriverError = iota + 1
+
+	// ErrNoDMA is returned if the driver has no DMA channel for the requested
+	// direction (see SetDMA).
+	ErrNoDMA
+)
+
+// Error implements error interface.
+func (e DriverError) Error() string {
+	switch e {
+	case ErrOverrun:
+		return "i2s: overrun"
+	case ErrNoDMA:
+		return "i2s: no DMA"
+	}
+	return ""
+}
+
+// Driver is a DMA based driver for the I2S peripheral. It streams PCM samples
+// to and from the pair of caller-supplied buffers (double buffering). Every
+// buffer contains the 32-bit samples of all enabled channels in the order:
+// left sample of the first enabled channel, right sample of the first enabled
+// channel, left sample of the next enabled channel and so on. The receive and
+// transmit streams can be used concurrently by two goroutines.
+type Driver struct {
+	p *Periph
+
+	rx, tx stream
+}
+
+type stream struct {
+	d    *dma.Driver
+	lli  []dma.LLI
+	buf  [2][]int32
+	next int // index of the buffer the DMAC works on
+}
+
+// NewDriver returns a new driver for p.
+func NewDriver(p *Periph) *Driver {
+	return &Driver{p: p}
+}
+
+func (d *Driver) Periph() *Periph {
+	return d.p
+}
+
+// SetDMA sets the DMA drivers used for transmission and reception. The DMAC
+// channels must be connected to the I2S request lines (see
+// dma.Channel.Connect, dmac0.AllocFor). Any of txd, rxd can be nil if the
+// corresponding direction is not used.
+func (d *Driver) SetDMA(txd, rxd *dma.Driver) {
+	d.tx.d, d.rx.d = txd, rxd
+}
+
+// DMA returns the DMA drivers used by d.
+func (d *Driver) DMA() (txd, rxd *dma.Driver) {
+	return d.tx.d, d.rx.d
+}
+
+// SetTimeout sets the timeout used by Rx and Tx.
+func (d *Driver) SetTimeout(timeout time.Duration) {
+	if d.tx.d != nil {
+		d.tx.d.SetTimeout(timeout)
+	}
+	if d.rx.d != nil {
+		d.rx.d.SetTimeout(timeout)
+	}
+}
+
+// Setup enables clock and resets the peripheral, configures the data format
+// and the number of SCLK cycles per word (see Conf). If sampleRate > 0 the
+// peripheral works in the master mode (generates SCLK and WS) and Setup sets
+// the clock divider to obtain the sample rate close to sampleRate, using the
+// current PLL2 frequency. Otherwise the peripheral works in the slave mode.
+// Setup returns the configured sample rate (0 in the slave mode).
+func (d *Driver) Setup(cfg Conf, sampleRate int) int {
+	p := d.p
+	p.EnableClock()
+	p.Reset()
+	p.Disable()
+	for i := range p.ch {
+		c := &p.ch[i]
+		c.DisableRx()
+		c.DisableTx()
+		c.SetIRQ(0)
+	}
+	p.SetConf(cfg&(gate|sclk|format) | SignExt)
+	p.Enable()
+	if sampleRate <= 0 {
+		p.DisableSCLK()
+		return 0
+	}
+	// round to the nearest even divider, SetClockDiv would round down
+	wclk := int64(p.SCLKPerWord() * 2 * sampleRate)
+	p.SetClockDiv(int((internal.PLLClock(2)+wclk)/(2*wclk)) * 2)
+	p.EnableSCLK()
+	return d.SampleRate()
+}
+
+// SampleRate returns the sample rate generated in the master mode.
+func (d *Driver) SampleRate() int {
+	return int(d.p.Clock() / int64(d.p.SCLKPerWord()*2))
+}
+
+// SetupRx configures the n-th channel to receive samples of wordLen bits (12,
+// 16, 20, 24, 32) or disables receiving by this channel if wordLen is 0.
+func (d *Driver) SetupRx(n, wordLen int) {
+	c := d.p.Channel(n)
+	if wordLen == 0 {
+		c.DisableRx()
+		return
+	}
+	c.SetRxWordLen(wordLen)
+	c.SetRxFIFOThr(FIFOLen/2 - 1)
+	c.EnableRx()
+}
+
+// SetupTx configures the n-th channel to transmit samples of wordLen bits (12,
+// 16, 20, 24, 32) or disables transmitting by this channel if wordLen is 0.
+func (d *Driver) SetupTx(n, wordLen int) {
+	c := d.p.Channel(n)
+	if wordLen == 0 {
+		c.DisableTx()
+		return
+	}
+	c.SetTxWordLen(wordLen)
+	c.SetTxFIFOThr(FIFOLen / 2)
+	c.EnableTx()
+}
+
+const dmaCtl = dma.SrcW32 | dma.DstW32 | dma.SrcB1 | dma.DstB1 | dma.IOCBlk
+
+func (s *stream) start(buf0, buf1 []int32, dr unsafe.Pointer, cfg dma.Conf) {
+	if len(buf0) == 0 || len(buf1) == 0 {
+		panic("i2s: empty buffer")
+	}
+	if s.lli == nil {
+		s.lli = dma.NewLLIs(2)
+	}
+	s.buf[0], s.buf[1] = buf0, buf1
+	for i := range s.buf {
+		buf := unsafe.Pointer(&s.buf[i][0])
+		n := len(s.buf[i])
+		next := &s.lli[i^1] // the items form a ring so the transfer never ends
+		if cfg == dma.PTM {
+			s.lli[i].Set(buf, dr, n, dmaCtl|dma.SrcNoInc, next)
+		} else {
+			s.lli[i].Set(dr, buf, n, dmaCtl|dma.DstNoInc, next)
+		}
+	}
+	s.next = 0
+	s.d.StartRing(s.lli, cfg)
+}
+
+// wait waits for the next block and returns the most recently processed
+// buffer.
+func (s *stream) wait() ([]int32, error) {
+	n, err := s.d.WaitBlock()
+	if err != nil {
+		return nil, err
+	}
+	i := (s.next + n - 1) & 1
+	s.next = i ^ 1
+	if n > 1 {
+		err = ErrOverrun
+	}
+	return s.buf[i], err
+}
+
+// StartRx starts continuous reception to buf0 and buf1 (DMA fills buf0, then
+// buf1, then buf0 again and so on). Use Rx to obtain the filled buffers. The
+// length of the buffers should be a multiple of the number of samples in the
+// frame (2 * number of channels enabled by SetupRx).
+func (d *Driver) StartRx(buf0, buf1 []int32) error {
+	if d.rx.d == nil {
+		return ErrNoDMA
+	}
+	p := d.p
+	p.DisableRx()
+	p.ResetRxFIFO()
+	p.ResetRxDMA()
+	p.ccr.SetBits(uint32(RxDMA))
+	d.rx.start(buf0, buf1, unsafe.Pointer(&p.rxdma), dma.PTM)
+	p.EnableRx()
+	return nil
+}
+
+// Rx waits for the next filled buffer and returns it. The returned buffer
+// belongs to the caller until DMA fills the other buffer (one buffer period)
+// so the caller should call Rx again before. Rx returns ErrOverrun (and the
+// most recently filled buffer) if some samples were lost.
+func (d *Driver) Rx() ([]int32, error) {
+	return d.rx.wait()
+}
+
+// StopRx stops the reception started by StartRx.
+func (d *Driver) StopRx() {
+	p := d.p
+	p.DisableRx()
+	d.rx.d.Stop()
+	p.ccr.ClearBits(uint32(RxDMA))
+	p.ResetRxFIFO()
+	d.rx.buf[0], d.rx.buf[1] = nil, nil
+}
+
+// StartTx starts continuous transmission from buf0 and buf1 (DMA sends buf0,
+// then buf1, then buf0 again and so on). Both buffers should be filled before
+// calling StartTx. Use Tx to obtain the buffers to be refilled.
+func (d *Driver) StartTx(buf0, buf1 []int32) error {
+	if d.tx.d == nil {
+		return ErrNoDMA
+	}
+	p := d.p
+	p.DisableTx()
+	p.ResetTxFIFO()
+	p.ResetTxDMA()
+	p.ccr.SetBits(uint32(TxDMA))
+	d.tx.start(buf0, buf1, unsafe.Pointer(&p.txdma), dma.MTP)
+	p.EnableTx()
+	return nil
+}
+
+// Tx waits until the next buffer has been sent and returns it. The returned
+// buffer belongs to the caller until DMA sends the other buffer (one buffer
+// period) so the caller should refill it and call Tx again before. Tx returns
+// ErrOverrun (and the most recently sent buffer) if some buffer has been sent
+// more than once.
+func (d *Driver) Tx() ([]int32, error) {
+	return d.tx.wait()
+}
+
+// StopTx stops the transmission started by StartTx.
+func (d *Driver) StopTx() {
+	p := d.p
+	d.tx.d.Stop()
+	p.DisableTx()
+	p.ccr.ClearBits(uint32(TxDMA))
+	p.ResetTxFIFO()
+	d.tx.buf[0], d.tx.buf[1] = nil, nil
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i2s1
+
+import (
+	"github.com/embeddedgo/kendryte/hal/i2s"
+	"github.com/embeddedgo/kendryte/hal/i2s/internal"
+)
+
+var driver *i2s.Driver
+
+// Driver returns a ready to use driver for I2S1 peripheral. Use EnableDMA to
+// allocate the DMAC channels before starting any stream.
+func Driver() *i2s.Driver {
+	if driver == nil {
+		driver = i2s.NewDriver(i2s.I2S(1))
+	}
+	return driver
+}
+
+// EnableDMA allocates the DMAC channels for the transmission (tx) and/or
+// reception (rx). It returns false if there are no free DMAC channels.
+func EnableDMA(tx, rx bool) bool {
+	return internal.EnableDMA(Driver(), 1, tx, rx)
+}
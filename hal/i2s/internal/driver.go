@@ -0,0 +1,35 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"github.com/embeddedgo/kendryte/hal/dma"
+	"github.com/embeddedgo/kendryte/hal/dma/dmac0"
+	"github.com/embeddedgo/kendryte/hal/i2s"
+)
+
+// EnableDMA allocates the DMAC channels for the enabled directions, connects
+// them to the I2Sn request lines and sets them in d. It returns false if
+// there are no free channels.
+func EnableDMA(d *i2s.Driver, n int, tx, rx bool) bool {
+	txd, rxd := d.DMA()
+	var txnew *dma.Driver
+	if tx && txd == nil {
+		if txd = dmac0.AllocFor(dma.I2S0_TX + dma.Request(n*2)); txd == nil {
+			return false
+		}
+		txnew = txd
+	}
+	if rx && rxd == nil {
+		if rxd = dmac0.AllocFor(dma.I2S0_RX + dma.Request(n*2)); rxd == nil {
+			if txnew != nil {
+				txnew.Channel().Free()
+			}
+			return false
+		}
+	}
+	d.SetDMA(txd, rxd)
+	return true
+}
@@ -0,0 +1,418 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package i2s provides interface to the I2S peripherals.
+package i2s
+
+import (
+	"embedded/mmio"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/bus"
+	"github.com/embeddedgo/kendryte/p/mmap"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// Synopsys DW_apb_i2s
+//
+//  K210 I2S features
+//  -----------------
+//	channels (stereo data lines)  4 (D0 to D3)
+//	FIFO depth                    8 stereo samples per channel
+//	DMA                           common Rx and Tx DMA registers
+
+// Periph represents I2S peripheral.
+type Periph struct {
+	ier    mmio.U32
+	irer   mmio.U32
+	iter   mmio.U32
+	cer    mmio.U32
+	ccr    mmio.U32
+	rxffr  mmio.U32
+	txffr  mmio.U32
+	_      uint32
+	ch     [NumChannel]Channel
+	_      [40]uint32
+	rxdma  mmio.U32
+	rrxdma mmio.U32
+	txdma  mmio.U32
+	rtxdma mmio.U32
+	_      [8]uint32
+
+	comp_param_2 mmio.U32
+	comp_param_1 mmio.U32
+	comp_version mmio.U32
+	comp_type    mmio.U32
+}
+
+// Channel represents the I2S channel (one stereo data line).
+type Channel struct {
+	left  mmio.U32
+	right mmio.U32
+	rer   mmio.U32
+	ter   mmio.U32
+	rcr   mmio.U32
+	tcr   mmio.U32
+	isr   mmio.U32
+	imr   mmio.U32
+	ror   mmio.U32
+	tor   mmio.U32
+	rfcr  mmio.U32
+	tfcr  mmio.U32
+	rff   mmio.U32
+	tff   mmio.U32
+	_     [2]uint32
+}
+
+// NumChannel is the number of channels in the I2S peripheral.
+const NumChannel = 4
+
+// FIFOLen is the depth of the Tx and Rx FIFOs (in stereo samples).
+const FIFOLen = 8
+
+// I2S returns n-th I2S peripheral (n = 0, 1, 2).
+func I2S(n int) *Periph {
+	if uint(n) > 2 {
+		panic("i2s: bad number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.I2S0_BASE + uintptr(n)*0x10000))
+}
+
+func (p *Periph) Bus() bus.Bus {
+	return bus.APB0
+}
+
+// n returns I2S number.
+func (p *Periph) n() uint {
+	return uint((uintptr(unsafe.Pointer(p)) - mmap.I2S0_BASE) / 0x10000)
+}
+
+func (p *Periph) EnableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_CENT.Lock()
+	if mx.APB0_CLK_EN == 0 {
+		sc.APB0_CLK_EN().Set()
+	}
+	mx.APB0_CLK_EN++
+	mx.CLK_EN_CENT.Unlock()
+
+	mx.CLK_EN_PERI.Lock()
+	sc.CLK_EN_PERI.SetBits(sysctl.I2S0_CLK_EN << p.n())
+	mx.CLK_EN_PERI.Unlock()
+}
+
+func (p *Periph) DisableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_PERI.Lock()
+	sc.CLK_EN_PERI.ClearBits(sysctl.I2S0_CLK_EN << p.n())
+	mx.CLK_EN_PERI.Unlock()
+
+	mx.CLK_EN_CENT.Lock()
+	mx.APB0_CLK_EN--
+	if mx.APB0_CLK_EN == 0 {
+		sc.APB0_CLK_EN().Clear()
+	}
+	mx.CLK_EN_CENT.Unlock()
+}
+
+func (p *Periph) Reset() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.PERI_RESET.Lock()
+	sc.PERI_RESET.SetBits(sysctl.I2S0_RESET << p.n())
+	mx.PERI_RESET.Unlock()
+
+	time.Sleep(10 * time.Microsecond)
+
+	mx.PERI_RESET.Lock()
+	sc.PERI_RESET.ClearBits(sysctl.I2S0_RESET << p.n())
+	mx.PERI_RESET.Unlock()
+}
+
+// clkTh returns the register and the field that contains the I2Sn clock
+// threshold.
+func (p *Periph) clkTh() (r *mmio.U32, mask uint32, shift uint) {
+	sc := sysctl.SYSCTL()
+	switch p.n() {
+	case 0:
+		return &sc.CLK_TH3.U32, uint32(sysctl.I2S0_CLK), sysctl.I2S0_CLKn
+	case 1:
+		return &sc.CLK_TH3.U32, uint32(sysctl.I2S1_CLK), sysctl.I2S1_CLKn
+	}
+	return &sc.CLK_TH4.U32, uint32(sysctl.I2S2_CLK), sysctl.I2S2_CLKn
+}
+
+// ClockDiv returns the current divider of the PLL2 clock (even number from 2
+// to 131072).
+func (p *Periph) ClockDiv() int {
+	r, mask, shift := p.clkTh()
+	return (int(r.LoadBits(mask)>>shift) + 1) * 2
+}
+
+// SetClockDiv sets the divider of the PLL2 clock. The div is rounded down to
+// the even number and clamped to the range from 2 to 131072.
+func (p *Periph) SetClockDiv(div int) {
+	th := div/2 - 1
+	if th < 0 {
+		th = 0
+	} else if th > 0xFFFF {
+		th = 0xFFFF
+	}
+	r, mask, shift := p.clkTh()
+	mx := &internal.MX.SYSCTL
+	mx.CLK_TH.Lock()
+	r.StoreBits(mask, uint32(th)<<shift)
+	mx.CLK_TH.Unlock()
+}
+
+// Clock returns the frequency of the peripheral clock in Hz. It is derived
+// from PLL2 and divided by the CLK_TH3/CLK_TH4 threshold (see ClockDiv). In
+// the master mode it is also the SCLK frequency.
+func (p *Periph) Clock() int64 {
+	return internal.PLLClock(2) / int64(p.ClockDiv())
+}
+
+// Enable enables the peripheral.
+func (p *Periph) Enable() {
+	p.ier.Store(1)
+}
+
+// Disable disables the peripheral.
+func (p *Periph) Disable() {
+	p.ier.Store(0)
+}
+
+// EnableRx enables the receiver block.
+func (p *Periph) EnableRx() {
+	p.irer.Store(1)
+}
+
+// DisableRx disables the receiver block.
+func (p *Periph) DisableRx() {
+	p.irer.Store(0)
+}
+
+// EnableTx enables the transmitter block.
+func (p *Periph) EnableTx() {
+	p.iter.Store(1)
+}
+
+// DisableTx disables the transmitter block.
+func (p *Periph) DisableTx() {
+	p.iter.Store(0)
+}
+
+// EnableSCLK enables generating SCLK and WS clocks (master mode).
+func (p *Periph) EnableSCLK() {
+	p.cer.Store(1)
+}
+
+// DisableSCLK disables generating SCLK and WS clocks (slave mode).
+func (p *Periph) DisableSCLK() {
+	p.cer.Store(0)
+}
+
+// Conf represents the configuration of the peripheral.
+type Conf uint16
+
+const (
+	Gate12 Conf = 1 << 0 // gate SCLK after 12 cycles
+	Gate16 Conf = 2 << 0 // gate SCLK after 16 cycles
+	Gate20 Conf = 3 << 0 // gate SCLK after 20 cycles
+	Gate24 Conf = 4 << 0 // gate SCLK after 24 cycles
+
+	SCLK16 Conf = 0 << 3 // 16 SCLK cycles per word (WS period is 32 cycles)
+	SCLK24 Conf = 1 << 3 // 24 SCLK cycles per word (WS period is 48 cycles)
+	SCLK32 Conf = 2 << 3 // 32 SCLK cycles per word (WS period is 64 cycles)
+
+	Std   Conf = 1 << 5 // standard (Philips) I2S format
+	Right Conf = 2 << 5 // right justified format
+	Left  Conf = 4 << 5 // left justified format
+
+	TxDMA   Conf = 1 << 8  // Tx DMA enabled
+	RxDMA   Conf = 1 << 9  // Rx DMA enabled
+	DMA16   Conf = 1 << 10 // DMA word contains left and right 16-bit sample
+	SignExt Conf = 1 << 11 // sign extend received samples to 32 bits
+
+	gate   = 7 << 0
+	sclk   = 3 << 3
+	format = 7 << 5
+)
+
+// Conf returns the current configuration.
+func (p *Periph) Conf() Conf {
+	return Conf(p.ccr.Load())
+}
+
+// SetConf sets the configuration.
+func (p *Periph) SetConf(cfg Conf) {
+	p.ccr.Store(uint32(cfg))
+}
+
+// SCLKPerWord returns the number of SCLK cycles per word (16, 24 or 32).
+func (p *Periph) SCLKPerWord() int {
+	return int(p.Conf()&sclk>>3)*8 + 16
+}
+
+// ResetRxFIFO resets the Rx FIFOs of all channels.
+func (p *Periph) ResetRxFIFO() {
+	p.rxffr.Store(1)
+}
+
+// ResetTxFIFO resets the Tx FIFOs of all channels.
+func (p *Periph) ResetTxFIFO() {
+	p.txffr.Store(1)
+}
+
+// ResetRxDMA resets the Rx DMA register so the next DMA read starts from the
+// left sample of the first enabled channel.
+func (p *Periph) ResetRxDMA() {
+	p.rrxdma.Store(1)
+}
+
+// ResetTxDMA resets the Tx DMA register so the next DMA write starts from the
+// left sample of the first enabled channel.
+func (p *Periph) ResetTxDMA() {
+	p.rtxdma.Store(1)
+}
+
+// Channel returns n-th channel.
+func (p *Periph) Channel(n int) *Channel {
+	return &p.ch[n]
+}
+
+// WordLen values.
+var wlen = [...]int8{0, 12, 16, 20, 24, 32}
+
+func wordLenBits(n int) uint32 {
+	for i, v := range wlen {
+		if int(v) == n {
+			return uint32(i)
+		}
+	}
+	panic("i2s: bad word length")
+}
+
+// SetRxWordLen sets the length of the received samples (12, 16, 20, 24, 32
+// bits or 0 to ignore the received data).
+func (c *Channel) SetRxWordLen(n int) {
+	c.rcr.Store(wordLenBits(n))
+}
+
+// RxWordLen returns the length of the received samples.
+func (c *Channel) RxWordLen() int {
+	return int(wlen[c.rcr.Load()&7%6])
+}
+
+// SetTxWordLen sets the length of the transmitted samples (12, 16, 20, 24, 32
+// bits or 0 to ignore the transmit data).
+func (c *Channel) SetTxWordLen(n int) {
+	c.tcr.Store(wordLenBits(n))
+}
+
+// TxWordLen returns the length of the transmitted samples.
+func (c *Channel) TxWordLen() int {
+	return int(wlen[c.tcr.Load()&7%6])
+}
+
+// EnableRx enables receiving by the channel.
+func (c *Channel) EnableRx() {
+	c.rer.Store(1)
+}
+
+// DisableRx disables receiving by the channel.
+func (c *Channel) DisableRx() {
+	c.rer.Store(0)
+}
+
+// EnableTx enables transmitting by the channel.
+func (c *Channel) EnableTx() {
+	c.ter.Store(1)
+}
+
+// DisableTx disables transmitting by the channel.
+func (c *Channel) DisableTx() {
+	c.ter.Store(0)
+}
+
+// SetRxFIFOThr sets the Rx FIFO threshold. The RxReady event and the DMA
+// request are generated when the number of samples in the Rx FIFO is greater
+// than thr.
+func (c *Channel) SetRxFIFOThr(thr int) {
+	c.rfcr.Store(uint32(thr))
+}
+
+// SetTxFIFOThr sets the Tx FIFO threshold. The TxEmpty event and the DMA
+// request are generated when the number of samples in the Tx FIFO is less than
+// or equal to thr.
+func (c *Channel) SetTxFIFOThr(thr int) {
+	c.tfcr.Store(uint32(thr))
+}
+
+// FlushRx flushes the channel Rx FIFO.
+func (c *Channel) FlushRx() {
+	c.rff.Store(1)
+}
+
+// FlushTx flushes the channel Tx FIFO.
+func (c *Channel) FlushTx() {
+	c.tff.Store(1)
+}
+
+// Event represents the channel interrupt events.
+type Event uint8
+
+const (
+	RxReady    Event = 1 << 0 // Rx FIFO level is above threshold
+	RxOverflow Event = 1 << 1 // Rx FIFO overflow, received data lost
+	TxEmpty    Event = 1 << 4 // Tx FIFO level is at or below threshold
+	TxOverflow Event = 1 << 5 // write to the full Tx FIFO
+
+	EvAll = RxReady | RxOverflow | TxEmpty | TxOverflow
+)
+
+// Events returns the pending events.
+func (c *Channel) Events() Event {
+	return Event(c.isr.Load())
+}
+
+// Clear clears the overflow events.
+func (c *Channel) Clear(ev Event) {
+	if ev&RxOverflow != 0 {
+		c.ror.Load()
+	}
+	if ev&TxOverflow != 0 {
+		c.tor.Load()
+	}
+}
+
+// IRQEnabled returns events that are enabled to generate interrupt request.
+func (c *Channel) IRQEnabled() Event {
+	return ^Event(c.imr.Load()) & EvAll
+}
+
+// SetIRQ sets the events that are enabled to generate interrupt request.
+func (c *Channel) SetIRQ(ev Event) {
+	c.imr.Store(uint32(^ev & EvAll))
+}
+
+// Load reads the left and right sample from the Rx FIFO.
+func (c *Channel) Load() (left, right uint32) {
+	left = c.left.Load()
+	right = c.right.Load()
+	return
+}
+
+// Store writes the left and right sample to the Tx FIFO.
+func (c *Channel) Store(left, right uint32) {
+	c.left.Store(left)
+	c.right.Store(right)
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package i2s
+
+import "github.com/embeddedgo/kendryte/hal/fpioa"
+
+type Signal uint8
+
+const (
+	MCLK Signal = iota // master clock
+	SCLK               // serial (bit) clock
+	WS                 // word select (left/right clock)
+	IN0                // data input of channel 0
+	IN1                // data input of channel 1
+	IN2                // data input of channel 2
+	IN3                // data input of channel 3
+	OUT0               // data output of channel 0
+	OUT1               // data output of channel 1
+	OUT2               // data output of channel 2
+	OUT3               // data output of channel 3
+)
+
+// UsePin is a helper function that can be used to configure FPIOA pins as
+// required by I2S peripheral. The SCLK and WS pins are configured as
+// bidirectional so they work in both the master and the slave mode.
+func (d *Driver) UsePin(pin fpioa.Pin, sig Signal) {
+	cfg := fpioa.I2S0_MCLK + fpioa.Config(d.p.n()*11) + fpioa.Config(sig)
+	switch {
+	case sig == SCLK || sig == WS:
+		cfg |= fpioa.DriveH34L23 | fpioa.EnOE | fpioa.EnIE | fpioa.Schmitt
+	case sig >= IN0 && sig <= IN3:
+		cfg |= fpioa.EnIE | fpioa.Schmitt
+	default:
+		cfg |= fpioa.DriveH34L23 | fpioa.EnOE
+	}
+	pin.Setup(cfg)
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import "github.com/embeddedgo/kendryte/p/sysctl"
+
+// ClockIn0 is the frequency of the external oscillator (IN0).
+const ClockIn0 = 26e6
+
+// PLLClock returns the current output frequency of the n-th PLL in Hz.
+func PLLClock(n int) int64 {
+	pll := sysctl.SYSCTL().PLL[n].Load()
+	in := int64(ClockIn0)
+	if n == 2 {
+		switch pll & sysctl.TEST_EN_CKIN_SEL >> sysctl.TEST_EN_CKIN_SELn {
+		case 1:
+			in = PLLClock(0)
+		case 2:
+			in = PLLClock(1)
+		}
+	}
+	if pll&sysctl.BYPASS != 0 {
+		return in
+	}
+	r := int64(pll&sysctl.CLKR>>sysctl.CLKRn + 1)
+	f := int64(pll&sysctl.CLKF>>sysctl.CLKFn + 1)
+	od := int64(pll&sysctl.CLKOD>>sysctl.CLKODn + 1)
+	return in * f / (r * od)
+}
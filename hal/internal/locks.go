@@ -10,8 +10,15 @@ var MX struct {
 	SYSCTL struct {
 		CLK_EN_CENT sync.Mutex
 		APB0_CLK_EN int
+		APB1_CLK_EN int
+		APB2_CLK_EN int
 
 		CLK_EN_PERI sync.Mutex
 		PERI_RESET  sync.Mutex
+		DMA_SEL     sync.Mutex
+		PERI        sync.Mutex
+		CLK_TH      sync.Mutex
+		PLL         sync.Mutex
 	}
+	I2C [3]sync.Mutex
 }
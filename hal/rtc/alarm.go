@@ -0,0 +1,160 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtc
+
+import (
+	"sync"
+	"time"
+)
+
+// MatchAll selects all calendar fields.
+const MatchAll = MatchSecond | MatchMinute | MatchHour | MatchDay | MatchMonth |
+	MatchYear
+
+// An Alarm delivers the times it matched on the channel C. Alarms are created
+// by Driver.NewAlarm.
+type Alarm struct {
+	C <-chan time.Time
+
+	c         chan time.Time
+	d         *Driver
+	t         time.Time
+	m         Match
+	recurring bool
+	next      time.Time
+}
+
+type scheduler struct {
+	mx     sync.Mutex
+	alarms []*Alarm
+	wake   chan struct{}
+}
+
+// NewAlarm returns an alarm that fires when the RTC time matches t in the
+// fields selected by m, e.g. MatchSecond fires at the beginning of every
+// minute, MatchSecond|MatchMinute|MatchHour fires once a day. A recurring
+// alarm is re-armed automatically for the next matching time, otherwise it is
+// stopped after the first event. The events are sent to the one element
+// buffered channel C. They are dropped if the receiver is too slow.
+//
+// The alarms share the single RTC alarm comparator so they cannot be used
+// together with SetAlarmFunc.
+func (d *Driver) NewAlarm(t time.Time, m Match, recurring bool) *Alarm {
+	t = t.UTC()
+	c := make(chan time.Time, 1)
+	a := &Alarm{C: c, c: c, d: d, t: t, m: m, recurring: recurring}
+	s := &d.sched
+	s.mx.Lock()
+	if s.wake == nil {
+		s.wake = make(chan struct{}, 1)
+		go d.runAlarms()
+	}
+	a.next = nextMatch(d.Time(), t, m)
+	s.alarms = append(s.alarms, a)
+	s.mx.Unlock()
+	d.wakeAlarms()
+	return a
+}
+
+// Stop stops the alarm. The alarm cannot be restarted.
+func (a *Alarm) Stop() {
+	s := &a.d.sched
+	s.mx.Lock()
+	for i, b := range s.alarms {
+		if b == a {
+			s.alarms = append(s.alarms[:i], s.alarms[i+1:]...)
+			break
+		}
+	}
+	s.mx.Unlock()
+	a.d.wakeAlarms()
+}
+
+// wakeAlarms wakes up the alarm scheduler. It can be called by the ISR.
+func (d *Driver) wakeAlarms() {
+	select {
+	case d.sched.wake <- struct{}{}:
+	default:
+	}
+}
+
+// runAlarms is the scheduler goroutine. It delivers the due alarms and sets
+// the RTC alarm to the earliest pending one.
+func (d *Driver) runAlarms() {
+	s := &d.sched
+	for range s.wake {
+		for {
+			now := d.Time()
+			var next time.Time
+			s.mx.Lock()
+			for i := 0; i < len(s.alarms); {
+				a := s.alarms[i]
+				if !a.next.IsZero() && !a.next.After(now) {
+					select {
+					case a.c <- a.next:
+					default:
+					}
+					if a.recurring {
+						a.next = nextMatch(now, a.t, a.m)
+					} else {
+						a.next = time.Time{}
+					}
+				}
+				if a.next.IsZero() {
+					// fired one-shot alarm or no matching time
+					s.alarms = append(s.alarms[:i], s.alarms[i+1:]...)
+					continue
+				}
+				if next.IsZero() || a.next.Before(next) {
+					next = a.next
+				}
+				i++
+			}
+			s.mx.Unlock()
+			if next.IsZero() {
+				d.SetAlarmFunc(time.Time{}, 0, nil)
+				break
+			}
+			d.SetAlarmFunc(next, MatchAll, d.wakeAlarms)
+			if d.Time().Before(next) {
+				break
+			}
+			// The alarm time passed during the setup.
+		}
+	}
+}
+
+// nextMatch returns the first time after now that matches t in the fields
+// selected by m. It returns the zero time if there is no such time in the
+// next hundred years.
+func nextMatch(now, t time.Time, m Match) time.Time {
+	n := now.Truncate(time.Second).Add(time.Second)
+	end := n.AddDate(100, 0, 0)
+	for n.Before(end) {
+		y, mo, d := n.Date()
+		h, mi, s := n.Clock()
+		switch {
+		case m&MatchYear != 0 && y != t.Year():
+			if y > t.Year() {
+				return time.Time{}
+			}
+			n = time.Date(y+1, 1, 1, 0, 0, 0, 0, time.UTC)
+		case m&MatchMonth != 0 && mo != t.Month():
+			n = time.Date(y, mo+1, 1, 0, 0, 0, 0, time.UTC)
+		case m&MatchDay != 0 && d != t.Day(),
+			m&MatchWeekday != 0 && n.Weekday() != t.Weekday():
+			n = time.Date(y, mo, d+1, 0, 0, 0, 0, time.UTC)
+		case m&MatchHour != 0 && h != t.Hour():
+			n = time.Date(y, mo, d, h+1, 0, 0, 0, time.UTC)
+		case m&MatchMinute != 0 && mi != t.Minute():
+			n = time.Date(y, mo, d, h, mi+1, 0, 0, time.UTC)
+		case m&MatchSecond != 0 && s != t.Second():
+			n = n.Add(time.Second)
+		default:
+			return n
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtc
+
+import (
+	"embedded/rtos"
+	"sync"
+	"time"
+
+	"github.com/embeddedgo/kendryte/hal/irq"
+)
+
+// Driver is an RTC driver. It keeps the RTC running at 1 Hz and handles the
+// tick and alarm interrupts.
+//
+// The tick and alarm callbacks are called by the interrupt handler so they
+// must be short and must not block.
+//
+// The driver methods can be called concurrently from multiple goroutines.
+type Driver struct {
+	mx    sync.Mutex // serializes the Setting mode and the time reads
+	p     *Periph
+	tick  func()
+	alarm func()
+	sched scheduler
+}
+
+// NewDriver returns a new driver for p.
+func NewDriver(p *Periph) *Driver {
+	return &Driver{p: p}
+}
+
+func (d *Driver) Periph() *Periph {
+	return d.p
+}
+
+// Start starts the RTC if it is not running or its prescaler does not match
+// the input clock. It preserves the calendar time kept by the running RTC.
+func (d *Driver) Start() {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+	p := d.p
+	clk := uint32(p.Clock())
+	if p.Mode() == Running && p.InitCount() == clk {
+		return
+	}
+	p.SetMode(Setting)
+	p.Unprotect()
+	p.SetInitCount(clk)
+	p.SetMode(Running)
+}
+
+// Time returns the calendar time kept by the RTC.
+func (d *Driver) Time() time.Time {
+	var t time.Time
+	d.locked(func(p *Periph) { t = p.Time() })
+	return t
+}
+
+// SetTime sets the RTC calendar time. The sub-second part of t is ignored.
+func (d *Driver) SetTime(t time.Time) {
+	d.setting(func(p *Periph) { p.SetTime(t) })
+}
+
+// SetTickFunc sets f to be called at the beginning of every second, minute,
+// hour or day according to u. The nil f disables the tick interrupt.
+func (d *Driver) SetTickFunc(u TickUnit, f func()) {
+	d.setting(func(p *Periph) {
+		d.tick = f
+		p.SetTickUnit(u)
+		if f != nil {
+			p.SetIRQ(p.IRQ() | TickEvent)
+		} else {
+			p.SetIRQ(p.IRQ() &^ TickEvent)
+		}
+	})
+}
+
+// SetAlarmFunc sets f to be called when the current time matches t in the
+// fields selected by m. The nil f disables the alarm interrupt.
+func (d *Driver) SetAlarmFunc(t time.Time, m Match, f func()) {
+	d.setting(func(p *Periph) {
+		d.alarm = f
+		p.SetAlarm(t, m)
+		if f != nil {
+			p.SetIRQ(p.IRQ() | AlarmEvent)
+		} else {
+			p.SetIRQ(p.IRQ() &^ AlarmEvent)
+		}
+	})
+}
+
+// setting runs f in the Setting mode with the driver locked and the RTC
+// interrupt disabled.
+func (d *Driver) setting(f func(p *Periph)) {
+	d.locked(func(p *Periph) {
+		m := p.Mode()
+		p.SetMode(Setting)
+		f(p)
+		p.SetMode(m)
+	})
+}
+
+// locked runs f with the driver locked and the RTC interrupt disabled (the
+// ISR switches the RTC to the Setting mode).
+func (d *Driver) locked(f func(p *Periph)) {
+	d.mx.Lock()
+	irq.RTC.Disable(irq.M0)
+	p := d.p
+	f(p)
+	if p.IRQ() != 0 {
+		irq.RTC.Enable(rtos.IntPrioLow, irq.M0)
+	}
+	d.mx.Unlock()
+}
+
+// tickDue reports whether the current time is the beginning of the tick
+// period.
+func tickDue(p *Periph) bool {
+	tim := p.time.Load()
+	switch p.TickUnit() {
+	case TickMinute:
+		return tim>>10&0x3F == 0
+	case TickHour:
+		return tim>>10&0xFFF == 0
+	case TickDay:
+		return tim>>10&0x7FFFF == 0
+	}
+	return true
+}
+
+// ISR is the RTC interrupt handler.
+func (d *Driver) ISR() {
+	p := d.p
+	e := p.IRQ()
+	m := p.Mode()
+	p.SetMode(Setting)
+	p.SetIRQ(0) // clear the pending interrupts
+	p.SetIRQ(e)
+	p.SetMode(m)
+	if e&AlarmEvent != 0 && d.alarm != nil && p.AlarmMatches() {
+		d.alarm()
+	}
+	if e&TickEvent != 0 && d.tick != nil && tickDue(p) {
+		d.tick()
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rtc provides interface to the Real Time Clock.
+//
+// The K210 RTC is clocked from IN0 and is powered from the main supply so it
+// keeps the calendar time across the system resets (see system.LastReset) but
+// not across the power cycles.
+package rtc
+
+import (
+	"embedded/mmio"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/bus"
+	"github.com/embeddedgo/kendryte/p/mmap"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// Periph represents the Real Time Clock.
+type Periph struct {
+	date      mmio.U32
+	time      mmio.U32
+	alarmDate mmio.U32
+	alarmTime mmio.U32
+	initCount mmio.U32
+	curCount  mmio.U32
+	intCtrl   mmio.U32
+	regCtrl   mmio.U32
+	_         [2]uint32
+	extended  mmio.U32
+}
+
+func RTC(n int) *Periph {
+	if n != 0 {
+		panic("rtc: bad number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.RTC_BASE))
+}
+
+func (p *Periph) Bus() bus.Bus {
+	return bus.APB1
+}
+
+func (p *Periph) EnableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_CENT.Lock()
+	if mx.APB1_CLK_EN == 0 {
+		sc.APB1_CLK_EN().Set()
+	}
+	mx.APB1_CLK_EN++
+	mx.CLK_EN_CENT.Unlock()
+
+	mx.CLK_EN_PERI.Lock()
+	sc.RTC_CLK_EN().Set()
+	mx.CLK_EN_PERI.Unlock()
+}
+
+func (p *Periph) DisableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_PERI.Lock()
+	sc.RTC_CLK_EN().Clear()
+	mx.CLK_EN_PERI.Unlock()
+
+	mx.CLK_EN_CENT.Lock()
+	mx.APB1_CLK_EN--
+	if mx.APB1_CLK_EN == 0 {
+		sc.APB1_CLK_EN().Clear()
+	}
+	mx.CLK_EN_CENT.Unlock()
+}
+
+// Reset resets the RTC. The calendar time is lost.
+func (p *Periph) Reset() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.PERI_RESET.Lock()
+	sc.RTC_RESET().Set()
+	mx.PERI_RESET.Unlock()
+
+	time.Sleep(10 * time.Microsecond)
+
+	mx.PERI_RESET.Lock()
+	sc.RTC_RESET().Clear()
+	mx.PERI_RESET.Unlock()
+}
+
+// Clock returns the RTC input clock frequency in Hz.
+func (p *Periph) Clock() int64 {
+	return internal.ClockIn0
+}
+
+// Mode represents the RTC timer mode.
+type Mode uint8
+
+const (
+	Pause   Mode = 0 // timer stopped, registers locked
+	Running Mode = 1 // timer running, time registers readable
+	Setting Mode = 2 // timer stopped, time and control registers writable
+)
+
+const (
+	readEnable  = 1 << 0
+	writeEnable = 1 << 1
+	maskAll     = 0x3FFFF << 13 // timer, alarm, initCount, intCtrl unmasked
+)
+
+// Mode returns the current timer mode.
+func (p *Periph) Mode() Mode {
+	return Mode(p.regCtrl.Load() & (readEnable | writeEnable))
+}
+
+// SetMode sets the timer mode. The change takes effect after a few RTC clock
+// cycles so SetMode reads back the control register to let it happen.
+func (p *Periph) SetMode(m Mode) {
+	p.regCtrl.StoreBits(readEnable|writeEnable, uint32(m))
+	p.regCtrl.Load()
+	p.regCtrl.Load()
+}
+
+// Unprotect makes all time and control registers writable in the Setting
+// mode.
+func (p *Periph) Unprotect() {
+	p.regCtrl.SetBits(maskAll)
+}
+
+// Protect makes all time and control registers read-only.
+func (p *Periph) Protect() {
+	p.regCtrl.ClearBits(maskAll)
+}
+
+// InitCount returns the prescaler reload value. The time is advanced by one
+// second every InitCount input clock cycles.
+func (p *Periph) InitCount() uint32 {
+	return p.initCount.Load()
+}
+
+// SetInitCount sets the prescaler reload value. It must be called in the
+// Setting mode.
+func (p *Periph) SetInitCount(n uint32) {
+	p.initCount.Store(n)
+}
+
+// Count returns the current value of the prescaler.
+func (p *Periph) Count() uint32 {
+	return p.curCount.Load()
+}
+
+// The date and time registers have the following layout:
+//
+//	date: weekday 0-2, day 8-12, month 16-19, year 20-31
+//	time: second 10-15, minute 16-21, hour 24-28
+//
+// The year field counts the years of the century and the century is stored
+// in the extended register (0-4) together with the leap year flag (5), as
+// in the Kendryte SDK: full year = century*100 + year.
+
+func encode(t time.Time) (date, tim, ext uint32) {
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	date = uint32(t.Weekday()) | uint32(d)<<8 | uint32(mo)<<16 |
+		uint32(y%100)<<20
+	tim = uint32(s)<<10 | uint32(mi)<<16 | uint32(h)<<24
+	ext = uint32(y/100) & 0x1F
+	if y%4 == 0 && (y%100 != 0 || y%400 == 0) {
+		ext |= 1 << 5
+	}
+	return
+}
+
+func decode(date, tim, ext uint32) time.Time {
+	y := int(ext&0x1F)*100 + int(date>>20&0xFFF)
+	mo := time.Month(date >> 16 & 0xF)
+	d := int(date >> 8 & 0x1F)
+	h := int(tim >> 24 & 0x1F)
+	mi := int(tim >> 16 & 0x3F)
+	s := int(tim >> 10 & 0x3F)
+	return time.Date(y, mo, d, h, mi, s, 0, time.UTC)
+}
+
+// Time returns the calendar time in UTC. It must be called in the Running
+// mode. Time reads the date twice to avoid a torn result at midnight.
+func (p *Periph) Time() time.Time {
+	for {
+		date := p.date.Load()
+		tim := p.time.Load()
+		ext := p.extended.Load()
+		if p.date.Load() == date {
+			return decode(date, tim, ext)
+		}
+	}
+}
+
+// SetTime sets the calendar time. It must be called in the Setting mode.
+func (p *Periph) SetTime(t time.Time) {
+	date, tim, ext := encode(t.UTC())
+	p.date.Store(date)
+	p.time.Store(tim)
+	p.extended.Store(ext)
+}
+
+// Match is a bitmask that selects the calendar fields compared by the alarm.
+type Match uint8
+
+const (
+	MatchSecond  Match = 1 << 1
+	MatchMinute  Match = 1 << 2
+	MatchHour    Match = 1 << 3
+	MatchWeekday Match = 1 << 4
+	MatchDay     Match = 1 << 5
+	MatchMonth   Match = 1 << 6
+	MatchYear    Match = 1 << 7
+)
+
+// Alarm returns the alarm time and the set of compared fields.
+func (p *Periph) Alarm() (t time.Time, m Match) {
+	ext := p.extended.Load()
+	t = decode(p.alarmDate.Load(), p.alarmTime.Load(), ext)
+	m = Match(p.intCtrl.Load() >> 24)
+	return
+}
+
+// SetAlarm sets the alarm time and the set of compared fields. It must be
+// called in the Setting mode.
+func (p *Periph) SetAlarm(t time.Time, m Match) {
+	date, tim, _ := encode(t.UTC())
+	p.alarmDate.Store(date)
+	p.alarmTime.Store(tim)
+	p.intCtrl.StoreBits(0xFF<<24, uint32(m)<<24)
+}
+
+// AlarmMatches reports whether the current time matches the alarm time in the
+// fields selected by the alarm mask. The RTC has no interrupt status register
+// so this is how the interrupt handler can recognize the alarm.
+func (p *Periph) AlarmMatches() bool {
+	m := uint32(p.intCtrl.Load() >> 24)
+	var dm, tm uint32
+	if m&uint32(MatchSecond) != 0 {
+		tm |= 0x3F << 10
+	}
+	if m&uint32(MatchMinute) != 0 {
+		tm |= 0x3F << 16
+	}
+	if m&uint32(MatchHour) != 0 {
+		tm |= 0x1F << 24
+	}
+	if m&uint32(MatchWeekday) != 0 {
+		dm |= 7
+	}
+	if m&uint32(MatchDay) != 0 {
+		dm |= 0x1F << 8
+	}
+	if m&uint32(MatchMonth) != 0 {
+		dm |= 0xF << 16
+	}
+	if m&uint32(MatchYear) != 0 {
+		dm |= 0xFFF << 20
+	}
+	return (p.time.Load()^p.alarmTime.Load())&tm == 0 &&
+		(p.date.Load()^p.alarmDate.Load())&dm == 0
+}
+
+// TickUnit determines the period of the tick interrupt.
+type TickUnit uint8
+
+const (
+	TickSecond TickUnit = 0
+	TickMinute TickUnit = 1
+	TickHour   TickUnit = 2
+	TickDay    TickUnit = 3
+)
+
+const (
+	tickEnable  = 1 << 0
+	alarmEnable = 1 << 1
+)
+
+// SetTickUnit sets the period of the tick interrupt. It must be called in the
+// Setting mode.
+func (p *Periph) SetTickUnit(u TickUnit) {
+	p.intCtrl.StoreBits(3<<2, uint32(u)<<2)
+}
+
+// TickUnit returns the period of the tick interrupt.
+func (p *Periph) TickUnit() TickUnit {
+	return TickUnit(p.intCtrl.Load() >> 2 & 3)
+}
+
+// Event is a bitmask that represents the RTC interrupt sources.
+type Event uint8
+
+const (
+	TickEvent  Event = tickEnable
+	AlarmEvent Event = alarmEnable
+)
+
+// IRQ returns the enabled interrupt sources.
+func (p *Periph) IRQ() Event {
+	return Event(p.intCtrl.Load() & (tickEnable | alarmEnable))
+}
+
+// SetIRQ sets the enabled interrupt sources. It must be called in the Setting
+// mode. The RTC interrupts have no status flags. A pending interrupt is
+// cleared by disabling its source.
+func (p *Periph) SetIRQ(e Event) {
+	p.intCtrl.StoreBits(tickEnable|alarmEnable, uint32(e))
+}
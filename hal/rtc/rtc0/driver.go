@@ -0,0 +1,31 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rtc0 provides a ready to use driver for the Real Time Clock.
+package rtc0
+
+import (
+	_ "unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/rtc"
+)
+
+var driver *rtc.Driver
+
+// Driver returns a ready to use and running driver for the RTC.
+func Driver() *rtc.Driver {
+	if driver == nil {
+		p := rtc.RTC(0)
+		p.EnableClock()
+		d := rtc.NewDriver(p)
+		d.Start()
+		driver = d
+	}
+	return driver
+}
+
+//go:interrupthandler
+func _RTC_Handler() { driver.ISR() }
+
+//go:linkname _RTC_Handler IRQ20_Handler
@@ -0,0 +1,96 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sha256
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/dma"
+)
+
+// Driver is a driver for the SHA-256 accelerator. It serializes the access to
+// the engine from concurrent goroutines. The message can be written to the
+// engine by DMAC if the driver has a DMA channel (see SetDMA).
+type Driver struct {
+	p  *Periph
+	mx sync.Mutex
+	dd *dma.Driver
+}
+
+// NewDriver returns a new driver for p.
+func NewDriver(p *Periph) *Driver {
+	return &Driver{p: p}
+}
+
+func (d *Driver) Periph() *Periph {
+	return d.p
+}
+
+// SetDMA sets the DMA channel used to write the 4-byte aligned message parts
+// at least DMAMinLen bytes long. The channel must be connected to the SHA
+// request line, e.g. d.SetDMA(dmac0.AllocFor(dma.SHA_RX)). The nil dd
+// disables DMA.
+func (d *Driver) SetDMA(dd *dma.Driver) {
+	d.mx.Lock()
+	d.dd = dd
+	d.mx.Unlock()
+}
+
+// DMA returns the DMA channel set by SetDMA.
+func (d *Driver) DMA() *dma.Driver {
+	return d.dd
+}
+
+// DMAMinLen is the minimum length of the message part written using DMA.
+const DMAMinLen = 256
+
+const dmaCtl = dma.SrcW32 | dma.DstW32 | dma.SrcB1 | dma.DstB1 | dma.DstNoInc
+
+// lock locks the engine and starts the digest of the size bytes long message.
+// The engine is reset first (as the SDK sha256_init does) so the state left by
+// an abandoned digest does not affect the new one.
+func (d *Driver) lock(size int64) {
+	d.mx.Lock()
+	d.p.Reset()
+	d.p.Start(int((size+8)/BlockSize + 1))
+}
+
+func (d *Driver) unlock() {
+	d.mx.Unlock()
+}
+
+// write writes the full blocks from b to the engine. It must be called with
+// the engine locked.
+func (d *Driver) write(b []byte) {
+	n := len(b)
+	if n == 0 {
+		return
+	}
+	p := d.p
+	if dd := d.dd; dd != nil && n >= DMAMinLen &&
+		uintptr(unsafe.Pointer(&b[0]))&3 == 0 {
+		p.SetDMA(true)
+		dd.Start(unsafe.Pointer(&p.dataIn), unsafe.Pointer(&b[0]), n/4, dmaCtl, dma.MTP)
+		err := dd.Wait()
+		p.SetDMA(false)
+		if err != nil {
+			panic("sha256: " + err.Error())
+		}
+		return
+	}
+	for i := 0; i < n; i += 4 {
+		p.Write(uint32(b[i]) | uint32(b[i+1])<<8 | uint32(b[i+2])<<16 |
+			uint32(b[i+3])<<24)
+	}
+}
+
+// Sum256 returns the SHA-256 checksum of the data.
+func (d *Driver) Sum256(data []byte) (sum [Size]byte) {
+	h := d.New(len(data))
+	h.Write(data)
+	h.Sum(sum[:0])
+	return
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sha256 provides interface to the SHA-256 accelerator.
+//
+// The K210 SHA-256 engine must know the number of the message blocks before
+// the first block is written so the New function takes the message size.
+package sha256
+
+import (
+	"embedded/mmio"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/bus"
+	"github.com/embeddedgo/kendryte/p/mmap"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// Periph represents the SHA-256 accelerator.
+type Periph struct {
+	result [8]mmio.U32
+	dataIn mmio.U32
+	_      uint32
+	num    mmio.U32
+	fn0    mmio.U32
+	_      uint32
+	fn1    mmio.U32
+}
+
+func SHA256(n int) *Periph {
+	if n != 0 {
+		panic("sha256: bad number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.SHA256_BASE))
+}
+
+func (p *Periph) Bus() bus.Bus {
+	return bus.APB0
+}
+
+func (p *Periph) EnableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_CENT.Lock()
+	if mx.APB0_CLK_EN == 0 {
+		sc.APB0_CLK_EN().Set()
+	}
+	mx.APB0_CLK_EN++
+	mx.CLK_EN_CENT.Unlock()
+
+	mx.CLK_EN_PERI.Lock()
+	sc.SHA_CLK_EN().Set()
+	mx.CLK_EN_PERI.Unlock()
+}
+
+func (p *Periph) DisableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_PERI.Lock()
+	sc.SHA_CLK_EN().Clear()
+	mx.CLK_EN_PERI.Unlock()
+
+	mx.CLK_EN_CENT.Lock()
+	mx.APB0_CLK_EN--
+	if mx.APB0_CLK_EN == 0 {
+		sc.APB0_CLK_EN().Clear()
+	}
+	mx.CLK_EN_CENT.Unlock()
+}
+
+func (p *Periph) Reset() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.PERI_RESET.Lock()
+	sc.SHA_RESET().Set()
+	mx.PERI_RESET.Unlock()
+
+	time.Sleep(10 * time.Microsecond)
+
+	mx.PERI_RESET.Lock()
+	sc.SHA_RESET().Clear()
+	mx.PERI_RESET.Unlock()
+}
+
+// MaxBlocks is the maximum number of blocks the engine can process in one
+// message.
+const MaxBlocks = 0xFFFF
+
+const (
+	shaEn     = 1 << 0
+	bigEndian = 1 << 16
+	dmaEn     = 1 << 0
+	fifoFull  = 1 << 8
+)
+
+// Start starts the computation of the new digest of the message that
+// consists of n 64-byte blocks (including the padding). The n must not exceed
+// MaxBlocks.
+func (p *Periph) Start(n int) {
+	if uint(n) > MaxBlocks {
+		panic("sha256: too many blocks")
+	}
+	p.num.StoreBits(0xFFFF, uint32(n))
+	p.fn1.ClearBits(dmaEn)
+	p.fn0.SetBits(bigEndian)
+	p.fn0.SetBits(shaEn)
+}
+
+// SetDMA enables or disables feeding the input FIFO by DMAC.
+func (p *Periph) SetDMA(en bool) {
+	if en {
+		p.fn1.SetBits(dmaEn)
+	} else {
+		p.fn1.ClearBits(dmaEn)
+	}
+}
+
+// Write waits for free space in the input FIFO and writes the next word of
+// the message. The bytes of the word are taken in little-endian order (the
+// engine works in big-endian mode).
+func (p *Periph) Write(w uint32) {
+	for p.fn1.Load()&fifoFull != 0 {
+	}
+	p.dataIn.Store(w)
+}
+
+// Done reports whether the engine has finished processing the message.
+func (p *Periph) Done() bool {
+	return p.fn0.Load()&shaEn != 0
+}
+
+// Sum waits for Done and returns the digest.
+func (p *Periph) Sum() (sum [32]byte) {
+	for !p.Done() {
+	}
+	for i := 0; i < 8; i++ {
+		w := p.result[7-i].Load()
+		sum[i*4] = byte(w)
+		sum[i*4+1] = byte(w >> 8)
+		sum[i*4+2] = byte(w >> 16)
+		sum[i*4+3] = byte(w >> 24)
+	}
+	return
+}
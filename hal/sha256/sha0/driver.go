@@ -0,0 +1,39 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sha0 provides a ready to use driver for the SHA-256 accelerator.
+package sha0
+
+import (
+	"github.com/embeddedgo/kendryte/hal/dma"
+	"github.com/embeddedgo/kendryte/hal/dma/dmac0"
+	"github.com/embeddedgo/kendryte/hal/sha256"
+)
+
+var driver *sha256.Driver
+
+// Driver returns a ready to use driver for the SHA-256 accelerator.
+func Driver() *sha256.Driver {
+	if driver == nil {
+		p := sha256.SHA256(0)
+		p.EnableClock()
+		driver = sha256.NewDriver(p)
+	}
+	return driver
+}
+
+// EnableDMA allocates a DMAC channel for writing the messages to the SHA-256
+// accelerator. It returns false if there is no free DMAC channel.
+func EnableDMA() bool {
+	d := Driver()
+	if d.DMA() != nil {
+		return true
+	}
+	dd := dmac0.AllocFor(dma.SHA_RX)
+	if dd == nil {
+		return false
+	}
+	d.SetDMA(dd)
+	return true
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sha256
+
+import (
+	gosha256 "crypto/sha256"
+	"hash"
+)
+
+// The size of a SHA-256 checksum in bytes.
+const Size = 32
+
+// The blocksize of SHA-256 in bytes.
+const BlockSize = 64
+
+// MaxSize is the maximum length of the message that fits in MaxBlocks blocks
+// together with the padding.
+const MaxSize = MaxBlocks*BlockSize - 9
+
+type digest struct {
+	d     *Driver
+	size  int64
+	n     int64
+	buf   [BlockSize]byte
+	nbuf  int
+	msg   []byte
+	sum   [Size]byte
+	state uint8
+}
+
+const (
+	idle uint8 = iota
+	running
+	done
+)
+
+// New returns a new hash.Hash computing the SHA-256 checksum of the size
+// bytes long message using the accelerator.
+//
+// If size >= 0 the written data is passed to the engine on the fly. The
+// engine is locked from the first Write until Sum so the other hashes that
+// use the same driver must wait (do not interleave them in one goroutine).
+// Write panics if the message is longer than size and Sum panics if it is
+// shorter. The result of Sum is cached so it may be called more than once.
+//
+// If size > MaxSize the engine cannot be used and New returns the software
+// implementation from the crypto/sha256 package.
+//
+// If size < 0 the message is buffered in memory and passed to the engine by
+// Sum (or hashed in software if it is longer than MaxSize). Use this mode if
+// the message length is not known in advance, e.g. for crypto/hmac:
+//
+//	mac := hmac.New(func() hash.Hash { return d.New(-1) }, key)
+func (d *Driver) New(size int) hash.Hash {
+	if size > MaxSize {
+		return gosha256.New()
+	}
+	return &digest{d: d, size: int64(size)}
+}
+
+func (h *digest) Size() int {
+	return Size
+}
+
+func (h *digest) BlockSize() int {
+	return BlockSize
+}
+
+func (h *digest) Reset() {
+	if h.state == running {
+		// Abandon the started digest. The engine is reset by the next
+		// user (see Driver.lock).
+		h.d.unlock()
+	}
+	h.state = idle
+	h.n = 0
+	h.nbuf = 0
+	h.msg = h.msg[:0]
+}
+
+func (h *digest) Write(b []byte) (int, error) {
+	if h.size < 0 {
+		h.msg = append(h.msg, b...)
+		return len(b), nil
+	}
+	n := len(b)
+	if h.n+int64(n) > h.size || h.state == done {
+		panic("sha256: message longer than declared")
+	}
+	if h.state == idle {
+		h.d.lock(h.size)
+		h.state = running
+	}
+	h.n += int64(n)
+	if h.nbuf != 0 {
+		k := copy(h.buf[h.nbuf:], b)
+		h.nbuf += k
+		b = b[k:]
+		if h.nbuf < BlockSize {
+			return n, nil
+		}
+		h.d.write(h.buf[:])
+		h.nbuf = 0
+	}
+	k := len(b) &^ (BlockSize - 1)
+	h.d.write(b[:k])
+	h.nbuf = copy(h.buf[:], b[k:])
+	return n, nil
+}
+
+func (h *digest) Sum(in []byte) []byte {
+	if h.size < 0 {
+		if len(h.msg) > MaxSize {
+			sum := gosha256.Sum256(h.msg)
+			return append(in, sum[:]...)
+		}
+		d := *h
+		d.size = int64(len(h.msg))
+		d.msg = nil
+		d.Write(h.msg)
+		return d.Sum(in)
+	}
+	if h.n != h.size {
+		panic("sha256: message shorter than declared")
+	}
+	if h.state != done {
+		if h.state == idle {
+			h.d.lock(h.size)
+		}
+		h.finish()
+		h.state = done
+	}
+	return append(in, h.sum[:]...)
+}
+
+// finish writes the remaining data and the padding to the engine, reads the
+// checksum and unlocks the engine.
+func (h *digest) finish() {
+	var tail [2 * BlockSize]byte
+	k := copy(tail[:], h.buf[:h.nbuf])
+	tail[k] = 0x80
+	k = (k + 1 + 8 + BlockSize - 1) &^ (BlockSize - 1)
+	bits := uint64(h.size) << 3
+	for i := 1; i <= 8; i++ {
+		tail[k-i] = byte(bits)
+		bits >>= 8
+	}
+	h.d.write(tail[:k])
+	h.sum = h.d.p.Sum()
+	h.d.unlock()
+}
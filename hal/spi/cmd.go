@@ -0,0 +1,130 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spi
+
+import "unsafe"
+
+// Cmd describes an enhanced SPI transaction that consists of instruction,
+// address, mode bits (XIP only), dummy cycles and data phases, as used by the QSPI/OPI NOR flash and
+// PSRAM memories. Any of the phases can be omitted by setting its length to
+// zero. For example the common Fast Read Quad I/O (1-4-4) flash command
+// can be described as:
+//
+//	Cmd{Format: Quad, Trans: InstStdAddrFF, InstLen: 8, AddrLen: 24, Dummy: 6}
+//
+// Use WriteRead in the standard frame format for the single line (1-1-1)
+// commands.
+type Cmd struct {
+	Format  Conf    // frame format of the data phase: Dual, Quad or Octal
+	Trans   SPIConf // format of the instruction and address phases
+	InstLen int     // instruction length in bits: 0, 4, 8 or 16
+	AddrLen int     // address length in bits: 0 to 32 (multiple of 4)
+	ModeLen int     // mode bits length (XIP only): 0, 2, 4, 8 or 16
+	Mode    uint16  // mode bits sent after the address (XIP only)
+	Dummy   int     // number of wait cycles (0 to 31) before the read data
+}
+
+// The transfer types must fit in the trans field (the constant conversions
+// below fail to compile otherwise).
+const (
+	_ = uint8(InstStdAddrFF&trans - InstStdAddrFF)
+	_ = uint8(InstFFAddrFF&trans - InstFFAddrFF)
+)
+
+func (c *Cmd) spiConf() SPIConf {
+	var inst SPIConf
+	switch c.InstLen {
+	case 0:
+		inst = Inst0
+	case 4:
+		inst = Inst4
+	case 8:
+		inst = Inst8
+	case 16:
+		inst = Inst16
+	default:
+		panic("spi: bad instruction length")
+	}
+	if c.AddrLen < 0 || c.AddrLen > 32 || c.AddrLen&3 != 0 {
+		panic("spi: bad address length")
+	}
+	if uint(c.Dummy) > 31 {
+		panic("spi: bad number of wait cycles")
+	}
+	if c.Trans&^trans != 0 || c.Trans == trans {
+		panic("spi: bad transfer type")
+	}
+	return c.Trans | inst | Addr(c.AddrLen) | Wait(c.Dummy)
+}
+
+// cmd performs the transaction described by c in the transfer mode tm.
+func (d *Driver) cmd(c *Cmd, inst, addr uint32, tm Conf, data unsafe.Pointer, n int, esize uintptr) (int, error) {
+	if c.Format&format == Std {
+		panic("spi: Cmd requires enhanced frame format")
+	}
+	if c.ModeLen != 0 {
+		panic("spi: mode bits supported in XIP mode only")
+	}
+	p := d.p
+	p.Disable()
+	cfg, scfg := p.Conf(), p.SPIConf()
+	p.SetConf(cfg&^format | c.Format&format)
+	p.SetSPIConf(c.spiConf())
+	var buf [2]uint32
+	pre := buf[:0]
+	if c.InstLen != 0 {
+		pre = append(pre, inst)
+	}
+	ai := -1
+	if c.AddrLen != 0 {
+		ai = len(pre)
+		pre = append(pre, addr)
+	}
+	if tm == TxOnly {
+		d.out, d.in, d.nout, d.nin, d.esize = data, nil, n, 0, esize
+	} else {
+		d.out, d.in, d.nout, d.nin, d.esize = nil, data, 0, n, esize
+	}
+	m, err := d.run(tm, n, pre, ai)
+	p.SetConf(cfg)
+	p.SetSPIConf(scfg)
+	return m, err
+}
+
+// WriteCmd performs the write transaction described by c. It sends the
+// instruction inst, the address addr and the data words from p (that can be
+// empty). It returns the number of data words sent. The c.Dummy field is
+// ignored (the wait cycles are supported by the read transactions only). The
+// data is always sent in one transaction, without DMA if it does not fit in
+// one DMA transfer (see SetDMA).
+func (d *Driver) WriteCmd(c *Cmd, inst, addr uint32, p []byte) (int, error) {
+	return d.cmd(c, inst, addr, TxOnly, unsafe.Pointer(unsafe.SliceData(p)), len(p), 1)
+}
+
+// WriteCmd32 works like WriteCmd but for data words up to 32 bits long.
+func (d *Driver) WriteCmd32(c *Cmd, inst, addr uint32, p []uint32) (int, error) {
+	return d.cmd(c, inst, addr, TxOnly, unsafe.Pointer(unsafe.SliceData(p)), len(p), 4)
+}
+
+// ReadCmd performs the read transaction described by c. It sends the
+// instruction inst and the address addr, waits c.Dummy cycles and receives
+// len(p) data words. It returns the number of data words received. Reads
+// longer than 65536 words are split into multiple transactions with the
+// address increased accordingly (the same applies to the DMA mode with the
+// data words shorter than 32 bits, see SetDMA).
+func (d *Driver) ReadCmd(c *Cmd, inst, addr uint32, p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return d.cmd(c, inst, addr, RxOnly, unsafe.Pointer(unsafe.SliceData(p)), len(p), 1)
+}
+
+// ReadCmd32 works like ReadCmd but for data words up to 32 bits long.
+func (d *Driver) ReadCmd32(c *Cmd, inst, addr uint32, p []uint32) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return d.cmd(c, inst, addr, RxOnly, unsafe.Pointer(unsafe.SliceData(p)), len(p), 4)
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spi
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/dma"
+)
+
+// DMA mode
+//
+// The SPI data register must be accessed by DMAC using 32-bit transfers, one
+// data frame per 32-bit word. The transfers that use []uint32 buffers (e.g.
+// WriteRead32) are performed directly. The other ones are performed through a
+// 32-bit bounce buffer, in chunks of its length.
+
+// DefaultBounceLen is the default length of the DMA bounce buffer (in words).
+// It is big enough to perform a whole 512-byte SD card block or a 256-byte
+// flash page program in one transaction.
+const DefaultBounceLen = 512
+
+// SetDMA enables the DMA mode for the transfers of at least minLen words using
+// the txd and rxd drivers. The DMAC channels must be connected to the SPI
+// request lines (see dma.Channel.Connect, dmac0.AllocFor). The bounceLen
+// specifies the size of the bounce buffer used for data words stored in the
+// []byte or []uint16 buffers (use DefaultBounceLen if unsure). SetDMA(nil,
+// nil, 0, 0) disables the DMA mode.
+func (d *Driver) SetDMA(txd, rxd *dma.Driver, minLen, bounceLen int) {
+	if txd == nil || rxd == nil {
+		d.txdma, d.rxdma, d.bounce = nil, nil, nil
+		return
+	}
+	if minLen < 1 {
+		minLen = 1
+	}
+	if bounceLen < 1 {
+		bounceLen = DefaultBounceLen
+	}
+	d.txdma, d.rxdma, d.dmaMin = txd, rxd, minLen
+	if len(d.bounce) != bounceLen {
+		d.bounce = make([]uint32, bounceLen)
+	}
+	txd.SetTimeout(d.timeout)
+	rxd.SetTimeout(d.timeout)
+}
+
+// DMA returns the DMA drivers used by d or nil, nil if d works in the
+// interrupt mode.
+func (d *Driver) DMA() (txd, rxd *dma.Driver) {
+	return d.txdma, d.rxdma
+}
+
+const (
+	rdmae = 1 << 0
+	tdmae = 1 << 1
+)
+
+var dmaDummy = dummy
+
+// xferDMA works like xfer but uses DMAC to move data between FIFOs and memory.
+func (d *Driver) xferDMA(tm Conf, n int, pre []uint32) (int, error) {
+	p := d.p
+	p.Disable()
+	p.storeCtrlr0Bits(tmod, tm)
+	p.ctrlr1.Store(uint32(n - 1))
+	p.ser.Store(0)
+	p.dmatdlr.Store(FIFOLen / 2)
+	p.dmardlr.Store(0)
+	p.Enable()
+
+	out, in := d.out, d.in
+	outInc := d.nout != 0
+	if !d.direct {
+		buf := d.bounce[:n]
+		for i := range buf {
+			v := dummy
+			if i < d.nout {
+				v = load(d.out, i, d.esize)
+			}
+			buf[i] = v
+		}
+		out, in, outInc = unsafe.Pointer(&buf[0]), unsafe.Pointer(&buf[0]), true
+	}
+	if tm == RxOnly && len(pre) == 0 {
+		p.dr[0].Store(dummy) // starts the receive only transfer
+	}
+	for _, v := range pre {
+		p.dr[0].Store(v)
+	}
+	dr := unsafe.Pointer(&p.dr[0])
+	const ctl = dma.SrcW32 | dma.DstW32 | dma.SrcB1 | dma.DstB1
+	var dmacr uint32
+	if tm != TxOnly {
+		d.rxdma.Start(in, dr, n, ctl|dma.SrcNoInc, dma.PTM)
+		dmacr |= rdmae
+	}
+	if tm != RxOnly {
+		c := ctl | dma.DstNoInc
+		if !outInc {
+			out, c = unsafe.Pointer(&dmaDummy), c|dma.SrcNoInc
+		}
+		d.txdma.Start(dr, out, n, c, dma.MTP)
+		dmacr |= tdmae
+	}
+	p.dmacr.Store(dmacr)
+	p.ser.Store(uint32(d.ss)) // starts the transfer
+
+	var err error
+	if tm != RxOnly {
+		err = d.txdma.Wait()
+	}
+	if tm != TxOnly {
+		if e := d.rxdma.Wait(); err == nil {
+			err = e
+		}
+	}
+	if err == nil {
+		for p.sr.Load()&uint32(TxEmpty|Busy) != uint32(TxEmpty) {
+			runtime.Gosched()
+		}
+	}
+	p.dmacr.Store(0)
+	p.Disable()
+	if err != nil {
+		return 0, err
+	}
+	if !d.direct && tm != TxOnly {
+		for i, v := range d.bounce[:d.nin] {
+			store(d.in, i, d.esize, v)
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,359 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spi
+
+import (
+	"embedded/rtos"
+	"runtime"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/dma"
+)
+
+type DriverError uint8
+
+const (
+	// ErrTimeout is returned if timeout occured. It means that the transfer
+	// has been interrupted and you can not determine the exact number of
+	// words sent to the slave.
+	ErrTimeout DriverError = iota + 1
+)
+
+// Error implements error interface.
+func (e DriverError) Error() string {
+	switch e {
+	case ErrTimeout:
+		return "spi: timeout"
+	}
+	return ""
+}
+
+// Driver is an interrupt based driver for the SPI master peripheral. It
+// supports one goroutine at a time.
+//
+// The DW_apb_ssi deactivates the slave select line when the Tx FIFO becomes
+// empty. The driver refills the FIFO in the interrupt handler before it runs
+// out of data but it cannot guarantee this in case of high SCLK frequency and
+// high interrupt latency. Use GPIO to control the slave select if the slave
+// requires it to be active during the whole transaction.
+type Driver struct {
+	p *Periph
+
+	out   unsafe.Pointer
+	in    unsafe.Pointer
+	nout  int
+	nin   int
+	wn    int // number of words to write to the Tx FIFO
+	rn    int // number of words to read from the Rx FIFO
+	nw    int
+	nr    int
+	esize uintptr
+	ss    uint8
+
+	txdma  *dma.Driver
+	rxdma  *dma.Driver
+	dmaMin int
+	bounce []uint32
+	dma    bool
+	direct bool
+
+	isr     uint32
+	done    rtos.Note
+	timeout time.Duration
+}
+
+// NewDriver returns a new driver for p.
+func NewDriver(p *Periph) *Driver {
+	return &Driver{p: p, ss: 1, timeout: -1}
+}
+
+func (d *Driver) Periph() *Periph {
+	return d.p
+}
+
+// Setup enables clock and resets the peripheral, sets the SPI mode (Mode0 to
+// Mode3), the frame format (Std, Dual, Quad, Octal) and the word length and
+// configures the SCLK frequency to the highest possible value that does not
+// exceed baudrate. The cfg may contain only mode and frame format bits. It
+// returns the configured SCLK frequency.
+func (d *Driver) Setup(cfg Conf, wordLen, baudrate int) int {
+	p := d.p
+	p.EnableClock()
+	p.Reset()
+	p.Disable()
+	p.SetIRQ(0)
+	p.SetConf(cfg & (mode | format))
+	p.SetWordLen(wordLen)
+	p.SetSPIConf(InstFFAddrFF | Inst0)
+	return p.SetBaudrate(baudrate)
+}
+
+// SetBaudrate sets the SCLK frequency to the highest possible value that does
+// not exceed br. It returns the frequency set.
+func (d *Driver) SetBaudrate(br int) int {
+	return d.p.SetBaudrate(br)
+}
+
+// SetWordLen sets the length of the data frame in bits (4 to 32).
+func (d *Driver) SetWordLen(n int) {
+	d.p.SetWordLen(n)
+}
+
+// SelectSlave selects the slave select line (0 to 3) activated by subsequent
+// transfers. If you control the slave select signal using GPIO select a line
+// that isn't connected to any pin (the peripheral requires at least one line
+// selected to start the transfer).
+func (d *Driver) SelectSlave(n int) {
+	if uint(n) > 3 {
+		panic("spi: bad slave select")
+	}
+	d.ss = 1 << uint(n)
+}
+
+// SetTimeout sets the timeout used by all transfer methods.
+func (d *Driver) SetTimeout(timeout time.Duration) {
+	d.timeout = timeout
+	if d.txdma != nil {
+		d.txdma.SetTimeout(timeout)
+		d.rxdma.SetTimeout(timeout)
+	}
+}
+
+// dummy is the word sent in the full-duplex mode if the output buffer is
+// shorter than the input one.
+const dummy = ^uint32(0)
+
+func load(p unsafe.Pointer, i int, esize uintptr) uint32 {
+	a := unsafe.Add(p, uintptr(i)*esize)
+	switch esize {
+	case 1:
+		return uint32(*(*uint8)(a))
+	case 2:
+		return uint32(*(*uint16)(a))
+	}
+	return *(*uint32)(a)
+}
+
+func store(p unsafe.Pointer, i int, esize uintptr, v uint32) {
+	a := unsafe.Add(p, uintptr(i)*esize)
+	switch esize {
+	case 1:
+		*(*uint8)(a) = uint8(v)
+	case 2:
+		*(*uint16)(a) = uint16(v)
+	default:
+		*(*uint32)(a) = v
+	}
+}
+
+// pump moves data between the buffers and FIFOs, sets the FIFO thresholds and
+// returns the events that should be enabled to continue the transfer or 0 if
+// the transfer is complete.
+func (d *Driver) pump() Event {
+	p := d.p
+	nr := d.nr
+	if nr < d.rn {
+		for m := int(p.rxflr.Load()); m > 0; m-- {
+			v := p.dr[0].Load()
+			if nr < d.nin {
+				store(d.in, nr, d.esize, v)
+			}
+			nr++
+		}
+		d.nr = nr
+	}
+	nw := d.nw
+	if nw < d.wn {
+		m := FIFOLen - int(p.txflr.Load())
+		if d.rn != 0 {
+			// full-duplex: avoid Rx FIFO overflow
+			if k := FIFOLen - (nw - nr); k < m {
+				m = k
+			}
+		}
+		if k := d.wn - nw; k < m {
+			m = k
+		}
+		for ; m > 0; m-- {
+			v := dummy
+			if nw < d.nout {
+				v = load(d.out, nw, d.esize)
+			}
+			p.dr[0].Store(v)
+			nw++
+		}
+		d.nw = nw
+	}
+	if d.rn != 0 {
+		if nr == d.rn {
+			return 0
+		}
+		k := d.rn - nr
+		if d.wn != 0 {
+			k = nw - nr // words in flight
+		}
+		if k > FIFOLen/2 {
+			k = FIFOLen / 2
+		}
+		p.rxftlr.Store(uint32(k - 1))
+		return RxHigh
+	}
+	if nw == d.wn {
+		if p.txflr.Load() == 0 {
+			return 0
+		}
+		p.txftlr.Store(0)
+	} else {
+		p.txftlr.Store(FIFOLen / 2)
+	}
+	return TxLow
+}
+
+// ISR handles the SPI interrupts.
+func (d *Driver) ISR() {
+	atomic.StoreUint32(&d.isr, 1)
+	p := d.p
+	if ev := d.pump(); ev != 0 {
+		p.imr.Store(uint32(ev))
+	} else {
+		p.imr.Store(0)
+		d.done.Wakeup()
+	}
+	atomic.StoreUint32(&d.isr, 0)
+}
+
+// xfer performs one transaction of n words in the transfer mode tm. The words
+// written to the Tx FIFO before the data (enhanced SPI instruction and
+// address) are passed in pre.
+func (d *Driver) xfer(tm Conf, n int, pre []uint32) (int, error) {
+	if d.dma {
+		return d.xferDMA(tm, n, pre)
+	}
+	p := d.p
+	p.Disable()
+	p.storeCtrlr0Bits(tmod, tm)
+	p.ctrlr1.Store(uint32(n - 1))
+	p.ser.Store(0)
+	p.Enable()
+	d.nw, d.nr = 0, 0
+	d.wn, d.rn = n, n
+	switch tm {
+	case TxOnly:
+		d.rn = 0
+	case RxOnly:
+		d.wn = 0
+		if len(pre) == 0 {
+			p.dr[0].Store(dummy) // starts the receive only transfer
+		}
+	}
+	for _, v := range pre {
+		p.dr[0].Store(v)
+	}
+	d.done.Clear()
+	ev := d.pump()
+	p.ser.Store(uint32(d.ss)) // starts the transfer
+	if ev == 0 {
+		d.done.Wakeup()
+	} else {
+		p.imr.Store(uint32(ev))
+	}
+	var err error
+	if !d.done.Sleep(d.timeout) {
+		p.imr.Store(0)
+		for atomic.LoadUint32(&d.isr) != 0 {
+			runtime.Gosched()
+		}
+		err = ErrTimeout
+	} else {
+		for p.sr.Load()&uint32(Busy) != 0 {
+			runtime.Gosched()
+		}
+	}
+	if tm == TxOnly {
+		if n = d.nw - int(p.txflr.Load()); n < 0 {
+			n = 0 // instruction or address not sent
+		}
+	} else {
+		n = d.nr
+	}
+	p.Disable()
+	return n, err
+}
+
+// run performs the transfer of n words in the transfer mode tm using the
+// buffers described by d.out, d.nout, d.in, d.nin. The transfer is split into
+// multiple transactions if it exceeds the CTRLR1 limit (RxOnly) or the size of
+// the DMA bounce buffer. If ai >= 0 then pre[ai] contains the address that is
+// increased accordingly in the subsequent transactions. A write command (pre
+// not empty) is never split, it is sent without DMA if it is too long.
+func (d *Driver) run(tm Conf, n int, pre []uint32, ai int) (int, error) {
+	out, in, nout, nin := d.out, d.in, d.nout, d.nin
+	esize := d.esize
+	d.dma = d.txdma != nil && n >= d.dmaMin
+	d.direct = esize == 4 && (nout == 0 || nout == n) && (nin == 0 || nin == n)
+	if d.dma && tm == TxOnly && len(pre) != 0 {
+		if d.direct && n > dma.MaxLen || !d.direct && n > len(d.bounce) {
+			d.dma = false
+		}
+	}
+	defer func() { d.out, d.in, d.dma = nil, nil, false }()
+	if n == 0 {
+		return d.xfer(tm, 0, pre)
+	}
+	var addr, wlen uint32
+	if ai >= 0 {
+		addr, wlen = pre[ai], uint32(d.p.WordLen()+7)/8
+	}
+	var (
+		done int
+		err  error
+	)
+	for done < n && err == nil {
+		m := n - done
+		if tm == RxOnly && m > 1<<16 {
+			m = 1 << 16
+		}
+		if d.dma {
+			if d.direct {
+				if m > dma.MaxLen {
+					m = dma.MaxLen
+				}
+			} else if m > len(d.bounce) {
+				m = len(d.bounce)
+			}
+		}
+		d.nout, d.nin = 0, 0
+		if nout > done {
+			d.out, d.nout = unsafe.Add(out, uintptr(done)*esize), min(nout-done, m)
+		}
+		if nin > done {
+			d.in, d.nin = unsafe.Add(in, uintptr(done)*esize), min(nin-done, m)
+		}
+		if ai >= 0 {
+			pre[ai] = addr + uint32(done)*wlen
+		}
+		m, err = d.xfer(tm, m, pre)
+		done += m
+	}
+	return done, err
+}
+
+// writeRead is the common implementation of WriteRead* methods.
+func (d *Driver) writeRead(out, in unsafe.Pointer, nout, nin int, esize uintptr) (int, error) {
+	d.out, d.in, d.nout, d.nin, d.esize = out, in, nout, nin, esize
+	switch {
+	case nout == 0 && nin == 0:
+		return 0, nil
+	case nin == 0:
+		return d.run(TxOnly, nout, nil, -1)
+	case d.p.Conf()&format == Std:
+		return d.run(TxRx, max(nout, nin), nil, -1)
+	case nout != 0:
+		panic("spi: full-duplex requires standard frame format")
+	}
+	return d.run(RxOnly, nin, nil, -1)
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spi
+
+import "unsafe"
+
+// WriteRead performs a SPI transaction. In the standard frame format it sends
+// the words from out and simultaneously stores the received words in in. The
+// number of words transferred is max(len(out), len(in)). If out is shorter
+// than in the remaining words are sent as all ones. In the dual, quad and
+// octal formats the transfer is half-duplex so one of out, in must be empty.
+// WriteRead returns the number of words transferred. Use WriteRead for words
+// up to 8 bits long.
+func (d *Driver) WriteRead(out, in []byte) (int, error) {
+	return d.writeRead(
+		unsafe.Pointer(unsafe.SliceData(out)), unsafe.Pointer(unsafe.SliceData(in)),
+		len(out), len(in), 1,
+	)
+}
+
+// WriteRead16 works like WriteRead but for words up to 16 bits long.
+func (d *Driver) WriteRead16(out, in []uint16) (int, error) {
+	return d.writeRead(
+		unsafe.Pointer(unsafe.SliceData(out)), unsafe.Pointer(unsafe.SliceData(in)),
+		len(out), len(in), 2,
+	)
+}
+
+// WriteRead32 works like WriteRead but for words up to 32 bits long.
+func (d *Driver) WriteRead32(out, in []uint32) (int, error) {
+	return d.writeRead(
+		unsafe.Pointer(unsafe.SliceData(out)), unsafe.Pointer(unsafe.SliceData(in)),
+		len(out), len(in), 4,
+	)
+}
+
+// Write implements io.Writer interface. It sends the words from p and ignores
+// the received ones.
+func (d *Driver) Write(p []byte) (int, error) {
+	return d.WriteRead(p, nil)
+}
+
+// WriteString works like Write but accepts string instead of byte slice.
+func (d *Driver) WriteString(s string) (int, error) {
+	return d.WriteRead(unsafe.Slice(unsafe.StringData(s), len(s)), nil)
+}
+
+// Read implements io.Reader interface. It receives len(p) words. In the
+// standard frame format all ones are sent at the same time.
+func (d *Driver) Read(p []byte) (int, error) {
+	return d.WriteRead(nil, p)
+}
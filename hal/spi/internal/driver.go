@@ -0,0 +1,42 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"embedded/rtos"
+
+	"github.com/embeddedgo/kendryte/hal/dma"
+	"github.com/embeddedgo/kendryte/hal/dma/dmac0"
+	"github.com/embeddedgo/kendryte/hal/irq"
+	"github.com/embeddedgo/kendryte/hal/spi"
+)
+
+// SPI returns a ready to use driver for SPIn peripheral.
+func SPI(n int, ir rtos.IRQ) *spi.Driver {
+	driver := spi.NewDriver(spi.SPI(n)) // must before ir.Enable
+	ctx := irq.M0
+	if ir&1 != 0 {
+		ctx = irq.M1
+	}
+	ir.Enable(rtos.IntPrioLow, ctx)
+	return driver
+}
+
+// EnableDMA allocates two DMAC channels, connects them to the SPIn request
+// lines and enables the DMA mode in d. It returns false and leaves d in the
+// interrupt mode if there are no free channels.
+func EnableDMA(d *spi.Driver, n, minLen int) bool {
+	txd := dmac0.AllocFor(dma.SPI0_TX + dma.Request(n*2))
+	if txd == nil {
+		return false
+	}
+	rxd := dmac0.AllocFor(dma.SPI0_RX + dma.Request(n*2))
+	if rxd == nil {
+		txd.Channel().Free()
+		return false
+	}
+	d.SetDMA(txd, rxd, minLen, spi.DefaultBounceLen)
+	return true
+}
@@ -0,0 +1,427 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package spi provides interface to the SPI master peripherals (SPI0, SPI1,
+// SPI3).
+package spi
+
+import (
+	"embedded/mmio"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/bus"
+	"github.com/embeddedgo/kendryte/p/mmap"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// Synopsys DW_apb_ssi (SPI0, SPI1), DWC_ssi (SPI3)
+//
+//  K210 SPI features
+//  -----------------
+//	FIFO depth         32 words
+//	max frame size     32 bits
+//	slave select lines 4
+//	frame formats      standard, dual, quad, octal (enhanced SPI)
+
+// Periph represents SPI peripheral.
+type Periph struct {
+	ctrlr0           mmio.U32
+	ctrlr1           mmio.U32
+	ssienr           mmio.U32
+	mwcr             mmio.U32
+	ser              mmio.U32
+	baudr            mmio.U32
+	txftlr           mmio.U32
+	rxftlr           mmio.U32
+	txflr            mmio.U32
+	rxflr            mmio.U32
+	sr               mmio.U32
+	imr              mmio.U32
+	isr              mmio.U32
+	risr             mmio.U32
+	txoicr           mmio.U32
+	rxoicr           mmio.U32
+	rxuicr           mmio.U32
+	msticr           mmio.U32
+	icr              mmio.U32
+	dmacr            mmio.U32
+	dmatdlr          mmio.U32
+	dmardlr          mmio.U32
+	idr              mmio.U32
+	ssic_version_id  mmio.U32
+	dr               [36]mmio.U32
+	rx_sample_delay  mmio.U32
+	spi_ctrlr0       mmio.U32
+	_                uint32
+	xip_mode_bits    mmio.U32
+	xip_incr_inst    mmio.U32
+	xip_wrap_inst    mmio.U32
+	xip_ctrl         mmio.U32
+	xip_ser          mmio.U32
+	xrxoicr          mmio.U32
+	xip_cnt_time_out mmio.U32
+	endian           mmio.U32
+}
+
+// FIFOLen is the depth of the Tx and Rx FIFOs in words.
+const FIFOLen = 32
+
+// SPI returns n-th SPI master peripheral. The valid numbers are 0, 1 and 3
+// (SPI2 is a slave only peripheral).
+func SPI(n int) *Periph {
+	var addr uintptr
+	switch n {
+	case 0:
+		addr = mmap.SPI0_BASE
+	case 1:
+		addr = mmap.SPI1_BASE
+	case 3:
+		addr = mmap.SPI3_BASE
+	default:
+		panic("spi: bad number")
+	}
+	return (*Periph)(unsafe.Pointer(addr))
+}
+
+func (p *Periph) Bus() bus.Bus {
+	return bus.APB2
+}
+
+// n returns the peripheral number (SPI0.n() = 0, SPI3.n() = 3).
+func (p *Periph) n() uint {
+	switch uintptr(unsafe.Pointer(p)) {
+	case mmap.SPI0_BASE:
+		return 0
+	case mmap.SPI1_BASE:
+		return 1
+	}
+	return 3
+}
+
+func (p *Periph) EnableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+	n := p.n()
+
+	if n != 3 {
+		mx.CLK_EN_CENT.Lock()
+		if mx.APB2_CLK_EN == 0 {
+			sc.APB2_CLK_EN().Set()
+		}
+		mx.APB2_CLK_EN++
+		mx.CLK_EN_CENT.Unlock()
+	}
+
+	mx.CLK_EN_PERI.Lock()
+	sc.CLK_EN_PERI.SetBits(sysctl.SPI0_CLK_EN << n)
+	mx.CLK_EN_PERI.Unlock()
+}
+
+func (p *Periph) DisableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+	n := p.n()
+
+	mx.CLK_EN_PERI.Lock()
+	sc.CLK_EN_PERI.ClearBits(sysctl.SPI0_CLK_EN << n)
+	mx.CLK_EN_PERI.Unlock()
+
+	if n != 3 {
+		mx.CLK_EN_CENT.Lock()
+		mx.APB2_CLK_EN--
+		if mx.APB2_CLK_EN == 0 {
+			sc.APB2_CLK_EN().Clear()
+		}
+		mx.CLK_EN_CENT.Unlock()
+	}
+}
+
+func (p *Periph) Reset() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.PERI_RESET.Lock()
+	sc.PERI_RESET.SetBits(sysctl.SPI0_RESET << p.n())
+	mx.PERI_RESET.Unlock()
+
+	time.Sleep(10 * time.Microsecond)
+
+	mx.PERI_RESET.Lock()
+	sc.PERI_RESET.ClearBits(sysctl.SPI0_RESET << p.n())
+	mx.PERI_RESET.Unlock()
+}
+
+// Clock returns the frequency of the peripheral clock (ssi_clk) in Hz. It is
+// derived from PLL0 (SPI3 can also use IN0) and divided by the CLK_TH1
+// threshold.
+func (p *Periph) Clock() int64 {
+	sc := sysctl.SYSCTL()
+	n := p.n()
+	clk := internal.PLLClock(0)
+	if n == 3 && sc.SPI3_CLK_SEL().Load() == 0 {
+		clk = internal.ClockIn0
+	}
+	th := int64(sc.CLK_TH1.Load()>>(n*8)&0xFF) + 1
+	return clk / (th * 2)
+}
+
+// Enable enables the peripheral. The configuration registers (see SetConf,
+// SetFrameNum, SetBaudrate, SetSPIConf) can be written only if the
+// peripheral is disabled. Disabling the peripheral halts the transfer in
+// progress and clears the FIFOs.
+func (p *Periph) Enable() {
+	p.ssienr.Store(1)
+}
+
+// Disable disables the peripheral.
+func (p *Periph) Disable() {
+	p.ssienr.Store(0)
+}
+
+// Enabled reports whether the peripheral is enabled.
+func (p *Periph) Enabled() bool {
+	return p.ssienr.Load()&1 != 0
+}
+
+// Conf represents the configuration of the frame and the transfer mode.
+type Conf uint32
+
+const (
+	CPHA Conf = 1 << 6 // capture data on the second clock edge
+	CPOL Conf = 1 << 7 // clock is high when idle
+
+	Mode0 Conf = 0           // CPOL=0 CPHA=0
+	Mode1 Conf = CPHA        // CPOL=0 CPHA=1
+	Mode2 Conf = CPOL        // CPOL=1 CPHA=0
+	Mode3 Conf = CPOL | CPHA // CPOL=1 CPHA=1
+
+	TxRx   Conf = 0 << 8 // transmit and receive (standard format only)
+	TxOnly Conf = 1 << 8 // transmit only
+	RxOnly Conf = 2 << 8 // receive only
+	EEPROM Conf = 3 << 8 // EEPROM read (standard format only)
+
+	Std   Conf = 0 << 21 // standard SPI frame format (D0=MOSI, D1=MISO)
+	Dual  Conf = 1 << 21 // dual SPI frame format (D0, D1)
+	Quad  Conf = 2 << 21 // quad SPI frame format (D0 to D3)
+	Octal Conf = 3 << 21 // octal SPI frame format (D0 to D7)
+
+	mode    = CPOL | CPHA
+	tmod    = EEPROM
+	format  = Octal
+	wordLen = 0x1F << 16
+)
+
+// SPI3 is DWC_ssi with different CTRLR0 layout than DW_apb_ssi used by SPI0
+// and SPI1. The Conf type uses the DW_apb_ssi layout.
+
+func (p *Periph) loadCtrlr0() Conf {
+	r := p.ctrlr0.Load()
+	if p.n() != 3 {
+		return Conf(r)
+	}
+	return Conf(r>>2)&(mode|tmod) | Conf(r>>1)&format | Conf(r<<16)&wordLen
+}
+
+func (p *Periph) storeCtrlr0Bits(mask, bits Conf) {
+	if p.n() == 3 {
+		mask = mask&(mode|tmod)<<2 | mask&format<<1 | mask&wordLen>>16
+		bits = bits&(mode|tmod)<<2 | bits&format<<1 | bits&wordLen>>16
+	}
+	p.ctrlr0.StoreBits(uint32(mask), uint32(bits))
+}
+
+// Conf returns the current configuration excluding the word length.
+func (p *Periph) Conf() Conf {
+	return p.loadCtrlr0() & (mode | tmod | format)
+}
+
+// SetConf sets the configuration. It does not change the word length.
+func (p *Periph) SetConf(cfg Conf) {
+	p.storeCtrlr0Bits(mode|tmod|format, cfg)
+}
+
+// WordLen returns the length of the data frame in bits.
+func (p *Periph) WordLen() int {
+	return int(p.loadCtrlr0()&wordLen>>16) + 1
+}
+
+// SetWordLen sets the length of the data frame in bits (4 to 32).
+func (p *Periph) SetWordLen(n int) {
+	if n < 4 || n > 32 {
+		panic("spi: bad word length")
+	}
+	p.storeCtrlr0Bits(wordLen, Conf(n-1)<<16)
+}
+
+// SetFrameNum sets the number of data frames to be received in the RxOnly and
+// EEPROM modes (1 to 65536).
+func (p *Periph) SetFrameNum(n int) {
+	p.ctrlr1.Store(uint32(n - 1))
+}
+
+// SetSlaves sets the bitmask of the slave select lines (bit n corresponds to
+// the SSn line) that will be activated during the transfer. The transfer does
+// not start until at least one line is selected.
+func (p *Periph) SetSlaves(mask uint8) {
+	p.ser.Store(uint32(mask))
+}
+
+// Slaves returns the bitmask of selected slave select lines.
+func (p *Periph) Slaves() uint8 {
+	return uint8(p.ser.Load())
+}
+
+// Baudrate returns the current SCLK frequency in Hz.
+func (p *Periph) Baudrate() int {
+	div := int64(p.baudr.Load())
+	if div == 0 {
+		return 0
+	}
+	return int(p.Clock() / div)
+}
+
+// SetBaudrate sets the SCLK frequency to the highest possible value that
+// does not exceed br. It returns the frequency set.
+func (p *Periph) SetBaudrate(br int) int {
+	clk := p.Clock()
+	div := (clk + int64(br) - 1) / int64(br)
+	div = (div + 1) &^ 1
+	if div < 2 {
+		div = 2
+	} else if div > 65534 {
+		div = 65534
+	}
+	p.baudr.Store(uint32(div))
+	return int(clk / div)
+}
+
+// TxFIFOLevel returns the number of words in the Tx FIFO.
+func (p *Periph) TxFIFOLevel() int {
+	return int(p.txflr.Load())
+}
+
+// RxFIFOLevel returns the number of words in the Rx FIFO.
+func (p *Periph) RxFIFOLevel() int {
+	return int(p.rxflr.Load())
+}
+
+// SetTxFIFOThr sets the Tx FIFO threshold. The TxLow event is generated when
+// the number of words in the Tx FIFO is less than or equal to thr.
+func (p *Periph) SetTxFIFOThr(thr int) {
+	p.txftlr.Store(uint32(thr))
+}
+
+// SetRxFIFOThr sets the Rx FIFO threshold. The RxHigh event is generated when
+// the number of words in the Rx FIFO is greater than thr.
+func (p *Periph) SetRxFIFOThr(thr int) {
+	p.rxftlr.Store(uint32(thr))
+}
+
+type Status uint8
+
+const (
+	Busy       Status = 1 << 0 // transfer in progress
+	TxNotFull  Status = 1 << 1 // Tx FIFO is not full
+	TxEmpty    Status = 1 << 2 // Tx FIFO is empty
+	RxNotEmpty Status = 1 << 3 // Rx FIFO is not empty
+	RxFull     Status = 1 << 4 // Rx FIFO is full
+)
+
+func (p *Periph) Status() Status {
+	return Status(p.sr.Load())
+}
+
+// Event represents the interrupt events.
+type Event uint8
+
+const (
+	TxLow       Event = 1 << 0 // Tx FIFO level is at or below threshold
+	TxOverflow  Event = 1 << 1 // write to the full Tx FIFO
+	RxUnderflow Event = 1 << 2 // read from the empty Rx FIFO
+	RxOverflow  Event = 1 << 3 // Rx FIFO overflow, received data lost
+	RxHigh      Event = 1 << 4 // Rx FIFO level is above threshold
+	MultiMaster Event = 1 << 5 // multi-master contention
+
+	EvAll = TxLow | TxOverflow | RxUnderflow | RxOverflow | RxHigh | MultiMaster
+)
+
+// Events returns the pending events that are enabled to generate interrupt
+// request.
+func (p *Periph) Events() Event {
+	return Event(p.isr.Load())
+}
+
+// RawEvents returns all pending events.
+func (p *Periph) RawEvents() Event {
+	return Event(p.risr.Load())
+}
+
+// Clear clears all pending error events (TxOverflow, RxUnderflow, RxOverflow,
+// MultiMaster). The TxLow and RxHigh events are cleared by the hardware when
+// the FIFO level crosses the threshold.
+func (p *Periph) Clear() {
+	p.icr.Load()
+}
+
+// IRQEnabled returns events that are enabled to generate interrupt request.
+func (p *Periph) IRQEnabled() Event {
+	return Event(p.imr.Load())
+}
+
+// SetIRQ sets the events that are enabled to generate interrupt request.
+func (p *Periph) SetIRQ(ev Event) {
+	p.imr.Store(uint32(ev))
+}
+
+// Load reads a word from the Rx FIFO.
+func (p *Periph) Load() uint32 {
+	return p.dr[0].Load()
+}
+
+// Store writes a word to the Tx FIFO.
+func (p *Periph) Store(v uint32) {
+	p.dr[0].Store(v)
+}
+
+// SPIConf represents the configuration of the enhanced (dual, quad, octal)
+// SPI transfers.
+type SPIConf uint32
+
+const (
+	// Transfer type: the frame format used by instruction and address.
+	InstStdAddrStd SPIConf = 0 // instruction and address in standard format
+	InstStdAddrFF  SPIConf = 1 // instruction in standard, address in Conf
+	InstFFAddrFF   SPIConf = 2 // instruction and address in Conf format
+
+	trans SPIConf = 3 // transfer type field
+
+	Inst0  SPIConf = 0 << 8 // no instruction
+	Inst4  SPIConf = 1 << 8 // 4-bit instruction
+	Inst8  SPIConf = 2 << 8 // 8-bit instruction
+	Inst16 SPIConf = 3 << 8 // 16-bit instruction
+)
+
+// Addr returns the SPIConf address length field for n-bit address (n must
+// be a multiple of 4 in the range 0 to 60).
+func Addr(n int) SPIConf {
+	return SPIConf(n/4) << 2
+}
+
+// Wait returns the SPIConf wait cycles field for n dummy cycles (0 to 31)
+// inserted between the address and the data phase.
+func Wait(n int) SPIConf {
+	return SPIConf(n) << 11
+}
+
+// SPIConf returns the configuration of the enhanced SPI transfers.
+func (p *Periph) SPIConf() SPIConf {
+	return SPIConf(p.spi_ctrlr0.Load())
+}
+
+// SetSPIConf sets the configuration of the enhanced SPI transfers.
+func (p *Periph) SetSPIConf(cfg SPIConf) {
+	p.spi_ctrlr0.Store(uint32(cfg))
+}
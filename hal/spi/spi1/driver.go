@@ -0,0 +1,35 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spi1
+
+import (
+	_ "unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/irq"
+	"github.com/embeddedgo/kendryte/hal/spi"
+	"github.com/embeddedgo/kendryte/hal/spi/internal"
+)
+
+var driver *spi.Driver
+
+// Driver returns a ready to use driver for SPI1 peripheral.
+func Driver() *spi.Driver {
+	if driver == nil {
+		driver = internal.SPI(1, irq.SPI1)
+	}
+	return driver
+}
+
+// EnableDMA enables the DMA mode for the transfers of at least minLen words.
+// It returns false if there are no free DMAC channels (the driver continues
+// to work in the interrupt mode).
+func EnableDMA(minLen int) bool {
+	return internal.EnableDMA(Driver(), 1, minLen)
+}
+
+//go:interrupthandler
+func _SPI1_Handler() { driver.ISR() }
+
+//go:linkname _SPI1_Handler IRQ2_Handler
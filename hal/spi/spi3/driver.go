@@ -0,0 +1,35 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spi3
+
+import (
+	_ "unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/irq"
+	"github.com/embeddedgo/kendryte/hal/spi"
+	"github.com/embeddedgo/kendryte/hal/spi/internal"
+)
+
+var driver *spi.Driver
+
+// Driver returns a ready to use driver for SPI3 peripheral.
+func Driver() *spi.Driver {
+	if driver == nil {
+		driver = internal.SPI(3, irq.SPI3)
+	}
+	return driver
+}
+
+// EnableDMA enables the DMA mode for the transfers of at least minLen words.
+// It returns false if there are no free DMAC channels (the driver continues
+// to work in the interrupt mode).
+func EnableDMA(minLen int) bool {
+	return internal.EnableDMA(Driver(), 3, minLen)
+}
+
+//go:interrupthandler
+func _SPI3_Handler() { driver.ISR() }
+
+//go:linkname _SPI3_Handler IRQ4_Handler
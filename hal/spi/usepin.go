@@ -0,0 +1,54 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spi
+
+import "github.com/embeddedgo/kendryte/hal/fpioa"
+
+type Signal uint8
+
+const (
+	D0 Signal = iota // MOSI in the standard frame format
+	D1               // MISO in the standard frame format
+	D2
+	D3
+	D4
+	D5
+	D6
+	D7
+	SS0
+	SS1
+	SS2
+	SS3
+	ARB
+	SCLK
+
+	MOSI = D0
+	MISO = D1
+)
+
+// UsePin is a helper function that can be used to configure FPIOA pins as
+// required by SPI peripheral. SPI3 is connected to the dedicated flash pins and
+// does not use FPIOA.
+func (d *Driver) UsePin(pin fpioa.Pin, sig Signal) {
+	var cfg fpioa.Config
+	switch d.p.n() {
+	case 0:
+		cfg = fpioa.SPI0_D0
+	case 1:
+		cfg = fpioa.SPI1_D0
+	default:
+		panic("spi: no FPIOA signals")
+	}
+	cfg += fpioa.Config(sig)
+	switch {
+	case sig <= D7:
+		cfg |= fpioa.DriveH34L23 | fpioa.EnOE | fpioa.EnIE | fpioa.Schmitt
+	case sig == ARB:
+		cfg |= fpioa.EnIE | fpioa.Schmitt
+	default:
+		cfg |= fpioa.DriveH34L23 | fpioa.EnOE
+	}
+	pin.Setup(cfg)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spi
+
+import (
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+const (
+	xipMdBitsEn = 1 << 12
+	xipInstEn   = 1 << 22
+)
+
+// SetXIPCmd configures the read command used in the execute-in-place (XIP)
+// mode. The XIP transfers use the c.Format frame format, the c.Trans
+// instruction/address format, the inst instruction (ignored if c.InstLen is
+// zero), c.AddrLen address bits, c.ModeLen mode bits (c.Mode) and c.Dummy wait
+// cycles. The data frame size is determined by the size of the bus access. The
+// peripheral must be disabled.
+func (p *Periph) SetXIPCmd(c *Cmd, inst uint32) {
+	scfg := c.spiConf()
+	ctrl := uint32(c.Format&format) >> 21
+	ctrl |= uint32(scfg&trans) << 2
+	ctrl |= uint32(c.AddrLen/4) << 4
+	ctrl |= uint32(scfg>>8&3) << 9
+	ctrl |= uint32(c.Dummy) << 13
+	if c.InstLen != 0 {
+		ctrl |= xipInstEn
+	}
+	if c.ModeLen != 0 {
+		var mbl uint32
+		switch c.ModeLen {
+		case 2:
+			mbl = 0
+		case 4:
+			mbl = 1
+		case 8:
+			mbl = 2
+		case 16:
+			mbl = 3
+		default:
+			panic("spi: bad mode bits length")
+		}
+		ctrl |= xipMdBitsEn | mbl<<26
+		p.xip_mode_bits.Store(uint32(c.Mode))
+	}
+	p.xip_ctrl.Store(ctrl)
+	p.xip_incr_inst.Store(inst)
+	p.xip_wrap_inst.Store(inst)
+}
+
+// SetXIPSlaves sets the bitmask of the slave select lines activated by the XIP
+// transfers.
+func (p *Periph) SetXIPSlaves(mask uint8) {
+	p.xip_ser.Store(uint32(mask))
+}
+
+// EnableXIP enables the XIP mode. All bus accesses to the peripheral address
+// space are treated as XIP reads where the offset from the peripheral base
+// address is the address sent to the memory. The peripheral registers are
+// not accessible until the XIP mode is disabled.
+func (p *Periph) EnableXIP() {
+	mx := &internal.MX.SYSCTL
+	mx.PERI.Lock()
+	sysctl.SYSCTL().PERI.SetBits(sysctl.SPI0_XIP_EN << p.n())
+	mx.PERI.Unlock()
+}
+
+// DisableXIP disables the XIP mode.
+func (p *Periph) DisableXIP() {
+	mx := &internal.MX.SYSCTL
+	mx.PERI.Lock()
+	sysctl.SYSCTL().PERI.ClearBits(sysctl.SPI0_XIP_EN << p.n())
+	mx.PERI.Unlock()
+}
+
+// XIPEnabled reports whether the XIP mode is enabled.
+func (p *Periph) XIPEnabled() bool {
+	return sysctl.SYSCTL().PERI.LoadBits(sysctl.SPI0_XIP_EN<<p.n()) != 0
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package xip provides read-only memory-mapped access to the boot flash
+// connected to SPI3 using the execute-in-place (XIP) mode of the peripheral.
+//
+// The SPI3 registers are not accessible while the XIP mode is enabled so the
+// spi3 driver cannot be used until Disable is called.
+package xip
+
+import (
+	"errors"
+	"io"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/spi"
+	"github.com/embeddedgo/kendryte/p/mmap"
+)
+
+// MaxSize is the size of the SPI3 address space available for XIP.
+const MaxSize = 16 << 20
+
+// ErrOffset is returned by ReadAt if the offset is negative.
+var ErrOffset = errors.New("xip: negative offset")
+
+// Flash represents the memory-mapped flash.
+type Flash struct {
+	mem []byte
+}
+
+// FastReadQuadIO describes the Fast Read Quad I/O (0xEB) command supported
+// by most of QSPI NOR flash memories, including the ones used on the K210
+// boards. Use it with inst = 0xEB. The command sends the mode bits M7-M0 = 0
+// after the address (2 clocks) followed by 4 dummy cycles. The explicitly
+// driven mode bits ensure the flash does not enter the continuous read mode
+// (M7-M4 = 0xA) so every XIP read starts with the instruction.
+var FastReadQuadIO = spi.Cmd{
+	Format:  spi.Quad,
+	Trans:   spi.InstStdAddrFF,
+	InstLen: 8,
+	AddrLen: 24,
+	ModeLen: 8,
+	Mode:    0x00,
+	Dummy:   4,
+}
+
+// Enable configures SPI3 to read the size bytes of flash memory using the read
+// command described by c, inst and baudrate and enables the XIP mode. The
+// flash must be already configured to accept the command (e.g. the QE bit
+// must be set for the quad commands). It returns the memory-mapped flash.
+func Enable(c *spi.Cmd, inst uint32, size, baudrate int) *Flash {
+	if uint(size) > MaxSize {
+		panic("xip: bad size")
+	}
+	p := spi.SPI(3)
+	if p.XIPEnabled() {
+		p.DisableXIP()
+	}
+	p.EnableClock()
+	p.Disable()
+	p.SetIRQ(0)
+	p.SetConf(spi.Mode0 | spi.RxOnly | c.Format)
+	p.SetWordLen(8)
+	p.SetBaudrate(baudrate)
+	p.SetXIPCmd(c, inst)
+	p.SetXIPSlaves(1)
+	p.Enable()
+	p.EnableXIP()
+	mem := unsafe.Slice((*byte)(unsafe.Pointer(mmap.SPI3_BASE)), size)
+	return &Flash{mem}
+}
+
+// Disable disables the XIP mode so SPI3 can be used in the normal way. The
+// previously returned Flash must not be used any more.
+func Disable() {
+	p := spi.SPI(3)
+	p.DisableXIP()
+	p.Disable()
+	p.DisableClock()
+}
+
+// Bytes returns the flash content as a byte slice. The slice must not be
+// modified.
+func (f *Flash) Bytes() []byte {
+	return f.mem
+}
+
+// Size returns the size of the mapped flash memory.
+func (f *Flash) Size() int64 {
+	return int64(len(f.mem))
+}
+
+// ReadAt implements io.ReaderAt interface.
+func (f *Flash) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, ErrOffset
+	}
+	if off >= int64(len(f.mem)) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.mem[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spislave
+
+import (
+	"embedded/rtos"
+	"sync/atomic"
+	"time"
+)
+
+type DriverError uint8
+
+const (
+	// ErrBufOverflow is returned if one or more received words has been
+	// dropped because of the lack of free space in the driver's receive
+	// buffer.
+	ErrBufOverflow DriverError = iota + 1
+
+	// ErrFIFOOverflow is returned if one or more received words has been
+	// dropped because the interrupt handler was not able to read the Rx FIFO
+	// on time.
+	ErrFIFOOverflow
+
+	// ErrTimeout is returned if timeout occured. In case of write it means
+	// that the master has not read all the data.
+	ErrTimeout
+)
+
+// Error implements error interface.
+func (e DriverError) Error() string {
+	switch e {
+	case ErrBufOverflow:
+		return "spislave: buffer overflow"
+	case ErrFIFOOverflow:
+		return "spislave: FIFO overflow"
+	case ErrTimeout:
+		return "spislave: timeout"
+	}
+	return ""
+}
+
+// Driver is an interrupt based driver for the SPI slave peripheral. It
+// provides the io.ReadWriter interface. The words longer than 8 bits are
+// stored in the little-endian order using 2 (9 to 16-bit word) or 4 (17 to
+// 32-bit word) bytes.
+//
+// The driver stays in the receive mode, storing all received words in its
+// internal ring buffer, except when it sends data using Write. The master
+// must know when the response is ready (e.g. by polling or using GPIO
+// signal) because the data line is not driven in the receive mode.
+type Driver struct {
+	p     *Periph
+	wb    int // bytes per word
+	fifoN int
+
+	// rx state
+	rxbuf   []byte
+	nextr   uint32
+	nextw   uint32
+	rxcmd   uint32
+	rxerr   uint32
+	rxready rtos.Note
+
+	// tx state
+	txdata []byte
+	txn    int
+	txdone rtos.Note
+
+	isr       uint32
+	timeoutRx time.Duration
+	timeoutTx time.Duration
+}
+
+// NewDriver returns a new driver for p.
+func NewDriver(p *Periph) *Driver {
+	return &Driver{p: p, wb: 1, timeoutRx: -1, timeoutTx: -1}
+}
+
+func (d *Driver) Periph() *Periph {
+	return d.p
+}
+
+const (
+	cmdNone = iota
+	cmdWakeup
+)
+
+// Setup enables clock and resets the peripheral, sets the SPI mode (Mode0 to
+// Mode3) and the word length (4 to 32 bits). The peripheral is left in the
+// receive mode but the received data are dropped until EnableRx is called.
+func (d *Driver) Setup(cfg Conf, wordLen int) {
+	p := d.p
+	p.EnableClock()
+	p.Reset()
+	p.Disable()
+	p.SetIRQ(0)
+	d.fifoN = p.FIFOLen()
+	p.SetWordLen(wordLen)
+	switch {
+	case wordLen <= 8:
+		d.wb = 1
+	case wordLen <= 16:
+		d.wb = 2
+	default:
+		d.wb = 4
+	}
+	p.SetConf(cfg&mode | RxOnly | NoOE)
+	p.SetRxFIFOThr(0)
+	p.Enable()
+}
+
+// SetReadTimeout sets the read timeout used by Read* functions.
+func (d *Driver) SetReadTimeout(timeout time.Duration) {
+	d.timeoutRx = timeout
+}
+
+// SetWriteTimeout sets the write timeout used by Write* functions.
+func (d *Driver) SetWriteTimeout(timeout time.Duration) {
+	d.timeoutTx = timeout
+}
+
+// ISR handles SPI slave interrupts.
+func (d *Driver) ISR() {
+	atomic.StoreUint32(&d.isr, 1)
+	p := d.p
+	ev := Event(p.isr.Load())
+	if ev&RxOverflow != 0 {
+		p.rxoicr.Load()
+		d.setRxErr(uint32(ErrFIFOOverflow))
+	}
+	if ev&RxHigh != 0 {
+		d.rxISR()
+	}
+	if ev&TxLow != 0 {
+		d.txISR()
+	}
+	atomic.StoreUint32(&d.isr, 0)
+}
+
+func (d *Driver) setRxErr(err uint32) {
+	for {
+		rxerr := atomic.LoadUint32(&d.rxerr)
+		if rxerr != 0 || atomic.CompareAndSwapUint32(&d.rxerr, 0, err) {
+			return
+		}
+	}
+}
+
+func (d *Driver) rxISR() {
+	p := d.p
+	n := len(d.rxbuf)
+	for m := int(p.rxflr.Load()); m > 0; m-- {
+		v := p.dr[0].Load()
+		nextw := int(d.nextw)
+		free := int(atomic.LoadUint32(&d.nextr)) - nextw - 1
+		if free < 0 {
+			free += n
+		}
+		if free < d.wb {
+			d.setRxErr(uint32(ErrBufOverflow))
+			continue
+		}
+		for i := 0; i < d.wb; i++ {
+			d.rxbuf[nextw] = byte(v)
+			v >>= 8
+			if nextw++; nextw == n {
+				nextw = 0
+			}
+		}
+		atomic.StoreUint32(&d.nextw, uint32(nextw))
+		if atomic.CompareAndSwapUint32(&d.rxcmd, cmdWakeup, cmdNone) {
+			d.rxready.Wakeup()
+		}
+	}
+}
+
+func (d *Driver) txISR() {
+	p := d.p
+	if d.txn >= len(d.txdata) {
+		if p.txflr.Load() == 0 {
+			p.imr.Store(0)
+			d.txdone.Wakeup()
+		}
+		return
+	}
+	for m := d.fifoN - int(p.txflr.Load()); m > 0 && d.txn < len(d.txdata); m-- {
+		var v uint32
+		for i := d.wb - 1; i >= 0; i-- {
+			v = v<<8 | uint32(d.txdata[d.txn+i])
+		}
+		p.dr[0].Store(v)
+		d.txn += d.wb
+	}
+	if d.txn >= len(d.txdata) {
+		p.txftlr.Store(0)
+	} else {
+		p.txftlr.Store(uint32(d.fifoN / 2))
+	}
+}
+
+func (d *Driver) rxMode() {
+	p := d.p
+	p.Disable()
+	p.SetConf(p.Conf()&mode | RxOnly | NoOE)
+	p.Enable()
+	if d.rxbuf != nil {
+		p.imr.Store(uint32(RxHigh | RxOverflow))
+	}
+}
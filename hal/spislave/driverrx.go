@@ -0,0 +1,107 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spislave
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// Len returns the number of bytes that are ready to read from Rx buffer.
+func (d *Driver) Len() int {
+	n := int(atomic.LoadUint32(&d.nextw)) - int(d.nextr)
+	if n < 0 {
+		n += len(d.rxbuf)
+	}
+	return n
+}
+
+// EnableRx enables storing of the received words in an internal ring buffer
+// of bufLen bytes. The buffer must be able to store at least one word. If the
+// buffer is full the ISR drops received words until there is free space for
+// them. EnableRx panics if the receiving is already enabled.
+func (d *Driver) EnableRx(bufLen int) {
+	if d.rxbuf != nil {
+		panic("spislave: enabled before")
+	}
+	if bufLen <= d.wb {
+		panic("spislave: rxbuf too short")
+	}
+	d.rxbuf = make([]byte, bufLen)
+	d.nextr = 0
+	d.nextw = 0
+	d.rxMode()
+}
+
+// DisableRx disables storing of the received words and frees memory allocated
+// for the internal ring buffer.
+func (d *Driver) DisableRx() {
+	d.p.SetIRQ(0)
+	for atomic.LoadUint32(&d.isr) != 0 {
+		runtime.Gosched()
+	}
+	d.rxbuf = nil
+}
+
+func (d *Driver) waitRxData() int {
+	nextw := atomic.LoadUint32(&d.nextw)
+	if nextw != d.nextr {
+		return int(nextw)
+	}
+	d.rxready.Clear()
+	atomic.StoreUint32(&d.rxcmd, cmdWakeup)
+	nextw = atomic.LoadUint32(&d.nextw)
+	if nextw != d.nextr {
+		if atomic.SwapUint32(&d.rxcmd, cmdNone) == cmdNone {
+			d.rxready.Sleep(-1) // wait for the upcoming wake up
+		}
+		return int(nextw)
+	}
+	if !d.rxready.Sleep(d.timeoutRx) {
+		if atomic.SwapUint32(&d.rxcmd, cmdNone) != cmdNone {
+			return int(nextw)
+		}
+		d.rxready.Sleep(-1) // wait for the upcoming wake up
+	}
+	nextw = atomic.LoadUint32(&d.nextw)
+	if nextw != d.nextr {
+		return int(nextw)
+	}
+	panic("spislave: wakeup on empty buffer")
+}
+
+func (d *Driver) markDataRead(nextr int) error {
+	if nextr >= len(d.rxbuf) {
+		nextr -= len(d.rxbuf)
+	}
+	atomic.StoreUint32(&d.nextr, uint32(nextr))
+	if rxerr := atomic.SwapUint32(&d.rxerr, 0); rxerr != 0 {
+		return DriverError(rxerr)
+	}
+	return nil
+}
+
+// Read reads up to len(p) bytes into p. It returns number of bytes read and an
+// error if detected. Read blocks only if the internal buffer is empty (d.Len()
+// > 0 ensure non-blocking read).
+func (d *Driver) Read(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	nextw := d.waitRxData()
+	nextr := int(d.nextr)
+	if nextw == nextr {
+		return 0, ErrTimeout
+	}
+	if nextr <= nextw {
+		n = copy(p, d.rxbuf[nextr:nextw])
+	} else {
+		n = copy(p, d.rxbuf[nextr:])
+		if n < len(p) {
+			n += copy(p[n:], d.rxbuf[:nextw])
+		}
+	}
+	return n, d.markDataRead(nextr + n)
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spislave
+
+import (
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Write switches the peripheral to the transmit mode and waits until the
+// master reads all words from p. The trailing bytes of p that do not form a
+// complete word are ignored. Write returns the number of bytes written to the
+// Tx FIFO which in case of timeout may be greater than the number of bytes
+// read by the master. The peripheral is switched back to the receive mode
+// before Write returns.
+func (d *Driver) Write(p []byte) (n int, err error) {
+	p = p[:len(p)/d.wb*d.wb]
+	if len(p) == 0 {
+		return 0, nil
+	}
+	per := d.p
+	per.SetIRQ(0)
+	for atomic.LoadUint32(&d.isr) != 0 {
+		runtime.Gosched()
+	}
+	per.Disable()
+	per.SetConf(per.Conf()&mode | TxOnly)
+	per.Enable()
+	d.txdata = p
+	d.txn = 0
+	d.txdone.Clear()
+	d.txISR() // fill the Tx FIFO before the master starts reading
+	per.SetIRQ(TxLow)
+	if !d.txdone.Sleep(d.timeoutTx) {
+		per.SetIRQ(0)
+		for atomic.LoadUint32(&d.isr) != 0 {
+			runtime.Gosched()
+		}
+		err = ErrTimeout
+	} else {
+		for per.Status()&Busy != 0 {
+			runtime.Gosched()
+		}
+	}
+	n = d.txn
+	d.txdata = nil
+	d.rxMode()
+	return n, err
+}
+
+// WriteString works like Write but accepts string instead of byte slice.
+func (d *Driver) WriteString(s string) (int, error) {
+	return d.Write(unsafe.Slice(unsafe.StringData(s), len(s)))
+}
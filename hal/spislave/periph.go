@@ -0,0 +1,276 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package spislave provides interface to the SPI slave peripheral (SPI2).
+//
+// The SPI2 slave has only one data line (D0) so it works in the half-duplex
+// mode: it either receives or transmits data.
+package spislave
+
+import (
+	"embedded/mmio"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/bus"
+	"github.com/embeddedgo/kendryte/p/mmap"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// Synopsys DW_apb_ssi (slave only configuration)
+
+// Periph represents SPI slave peripheral.
+type Periph struct {
+	ctrlr0  mmio.U32
+	_       uint32
+	ssienr  mmio.U32
+	mwcr    mmio.U32
+	_       uint32
+	_       uint32
+	txftlr  mmio.U32
+	rxftlr  mmio.U32
+	txflr   mmio.U32
+	rxflr   mmio.U32
+	sr      mmio.U32
+	imr     mmio.U32
+	isr     mmio.U32
+	risr    mmio.U32
+	txoicr  mmio.U32
+	rxoicr  mmio.U32
+	rxuicr  mmio.U32
+	msticr  mmio.U32
+	icr     mmio.U32
+	dmacr   mmio.U32
+	dmatdlr mmio.U32
+	dmardlr mmio.U32
+	idr     mmio.U32
+	version mmio.U32
+	dr      [36]mmio.U32
+}
+
+// SPI returns the n-th SPI slave peripheral. The only valid number is 2.
+func SPI(n int) *Periph {
+	if n != 2 {
+		panic("spislave: bad number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.SPI2_BASE))
+}
+
+func (p *Periph) Bus() bus.Bus {
+	return bus.APB0
+}
+
+func (p *Periph) EnableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_CENT.Lock()
+	if mx.APB0_CLK_EN == 0 {
+		sc.APB0_CLK_EN().Set()
+	}
+	mx.APB0_CLK_EN++
+	mx.CLK_EN_CENT.Unlock()
+
+	mx.CLK_EN_PERI.Lock()
+	sc.CLK_EN_PERI.SetBits(sysctl.SPI2_CLK_EN)
+	mx.CLK_EN_PERI.Unlock()
+}
+
+func (p *Periph) DisableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_PERI.Lock()
+	sc.CLK_EN_PERI.ClearBits(sysctl.SPI2_CLK_EN)
+	mx.CLK_EN_PERI.Unlock()
+
+	mx.CLK_EN_CENT.Lock()
+	mx.APB0_CLK_EN--
+	if mx.APB0_CLK_EN == 0 {
+		sc.APB0_CLK_EN().Clear()
+	}
+	mx.CLK_EN_CENT.Unlock()
+}
+
+func (p *Periph) Reset() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.PERI_RESET.Lock()
+	sc.PERI_RESET.SetBits(sysctl.SPI2_RESET)
+	mx.PERI_RESET.Unlock()
+
+	time.Sleep(10 * time.Microsecond)
+
+	mx.PERI_RESET.Lock()
+	sc.PERI_RESET.ClearBits(sysctl.SPI2_RESET)
+	mx.PERI_RESET.Unlock()
+}
+
+// Clock returns the frequency of the peripheral clock (ssi_clk) in Hz. The
+// SCLK frequency generated by the master must not exceed Clock()/8.
+func (p *Periph) Clock() int64 {
+	th := int64(sysctl.SYSCTL().CLK_TH1.LoadBits(sysctl.SPI2_CLK)>>sysctl.SPI2_CLKn) + 1
+	return internal.PLLClock(0) / (th * 2)
+}
+
+// Enable enables the peripheral. The configuration (see SetConf, SetWordLen)
+// can be changed only if the peripheral is disabled. Disabling the peripheral
+// clears the FIFOs.
+func (p *Periph) Enable() {
+	p.ssienr.Store(1)
+}
+
+// Disable disables the peripheral.
+func (p *Periph) Disable() {
+	p.ssienr.Store(0)
+}
+
+// Conf represents the configuration of the frame and the transfer mode.
+type Conf uint32
+
+const (
+	CPHA Conf = 1 << 6 // capture data on the second clock edge
+	CPOL Conf = 1 << 7 // clock is high when idle
+
+	Mode0 Conf = 0           // CPOL=0 CPHA=0
+	Mode1 Conf = CPHA        // CPOL=0 CPHA=1
+	Mode2 Conf = CPOL        // CPOL=1 CPHA=0
+	Mode3 Conf = CPOL | CPHA // CPOL=1 CPHA=1
+
+	TxRx   Conf = 0 << 8 // transmit and receive
+	TxOnly Conf = 1 << 8 // transmit only
+	RxOnly Conf = 2 << 8 // receive only
+
+	NoOE Conf = 1 << 10 // slave output (D0) disabled
+
+	mode    = CPOL | CPHA
+	tmod    = 3 << 8
+	wordLen = 0x1F << 16
+)
+
+// Conf returns the current configuration excluding the word length.
+func (p *Periph) Conf() Conf {
+	return Conf(p.ctrlr0.Load()) & (mode | tmod | NoOE)
+}
+
+// SetConf sets the configuration. It does not change the word length.
+func (p *Periph) SetConf(cfg Conf) {
+	p.ctrlr0.StoreBits(uint32(mode|tmod|NoOE), uint32(cfg))
+}
+
+// WordLen returns the length of the data frame in bits.
+func (p *Periph) WordLen() int {
+	return int(p.ctrlr0.LoadBits(uint32(wordLen))>>16) + 1
+}
+
+// SetWordLen sets the length of the data frame in bits (4 to 32).
+func (p *Periph) SetWordLen(n int) {
+	if n < 4 || n > 32 {
+		panic("spislave: bad word length")
+	}
+	p.ctrlr0.StoreBits(uint32(wordLen), uint32(n-1)<<16)
+}
+
+// FIFOLen returns the depth of the Tx FIFO. It determines the depth by writing
+// to the Tx FIFO threshold register so it must not be called during transfer.
+func (p *Periph) FIFOLen() int {
+	n := 1
+	for ; n < 256; n++ {
+		p.txftlr.Store(uint32(n))
+		if p.txftlr.Load() != uint32(n) {
+			break
+		}
+	}
+	p.txftlr.Store(0)
+	return n
+}
+
+// TxFIFOLevel returns the number of words in the Tx FIFO.
+func (p *Periph) TxFIFOLevel() int {
+	return int(p.txflr.Load())
+}
+
+// RxFIFOLevel returns the number of words in the Rx FIFO.
+func (p *Periph) RxFIFOLevel() int {
+	return int(p.rxflr.Load())
+}
+
+// SetTxFIFOThr sets the Tx FIFO threshold. The TxLow event is generated when
+// the number of words in the Tx FIFO is less than or equal to thr.
+func (p *Periph) SetTxFIFOThr(thr int) {
+	p.txftlr.Store(uint32(thr))
+}
+
+// SetRxFIFOThr sets the Rx FIFO threshold. The RxHigh event is generated when
+// the number of words in the Rx FIFO is greater than thr.
+func (p *Periph) SetRxFIFOThr(thr int) {
+	p.rxftlr.Store(uint32(thr))
+}
+
+type Status uint8
+
+const (
+	Busy       Status = 1 << 0 // transfer in progress
+	TxNotFull  Status = 1 << 1 // Tx FIFO is not full
+	TxEmpty    Status = 1 << 2 // Tx FIFO is empty
+	RxNotEmpty Status = 1 << 3 // Rx FIFO is not empty
+	RxFull     Status = 1 << 4 // Rx FIFO is full
+	TxErr      Status = 1 << 5 // Tx FIFO was empty when the transfer started
+)
+
+func (p *Periph) Status() Status {
+	return Status(p.sr.Load())
+}
+
+// Event represents the interrupt events.
+type Event uint8
+
+const (
+	TxLow       Event = 1 << 0 // Tx FIFO level is at or below threshold
+	TxOverflow  Event = 1 << 1 // write to the full Tx FIFO
+	RxUnderflow Event = 1 << 2 // read from the empty Rx FIFO
+	RxOverflow  Event = 1 << 3 // Rx FIFO overflow, received data lost
+	RxHigh      Event = 1 << 4 // Rx FIFO level is above threshold
+
+	EvAll = TxLow | TxOverflow | RxUnderflow | RxOverflow | RxHigh
+)
+
+// Events returns the pending events that are enabled to generate interrupt
+// request.
+func (p *Periph) Events() Event {
+	return Event(p.isr.Load())
+}
+
+// RawEvents returns all pending events.
+func (p *Periph) RawEvents() Event {
+	return Event(p.risr.Load())
+}
+
+// Clear clears all pending error events (TxOverflow, RxUnderflow,
+// RxOverflow).
+func (p *Periph) Clear() {
+	p.icr.Load()
+}
+
+// IRQEnabled returns events that are enabled to generate interrupt request.
+func (p *Periph) IRQEnabled() Event {
+	return Event(p.imr.Load())
+}
+
+// SetIRQ sets the events that are enabled to generate interrupt request.
+func (p *Periph) SetIRQ(ev Event) {
+	p.imr.Store(uint32(ev))
+}
+
+// Load reads a word from the Rx FIFO.
+func (p *Periph) Load() uint32 {
+	return p.dr[0].Load()
+}
+
+// Store writes a word to the Tx FIFO.
+func (p *Periph) Store(v uint32) {
+	p.dr[0].Store(v)
+}
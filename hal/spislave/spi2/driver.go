@@ -0,0 +1,29 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spi2
+
+import (
+	"embedded/rtos"
+	_ "unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/irq"
+	"github.com/embeddedgo/kendryte/hal/spislave"
+)
+
+var driver *spislave.Driver
+
+// Driver returns a ready to use driver for SPI2 slave peripheral.
+func Driver() *spislave.Driver {
+	if driver == nil {
+		driver = spislave.NewDriver(spislave.SPI(2))
+		irq.SPI_SLAVE.Enable(rtos.IntPrioLow, irq.M1)
+	}
+	return driver
+}
+
+//go:interrupthandler
+func _SPI_SLAVE_Handler() { driver.ISR() }
+
+//go:linkname _SPI_SLAVE_Handler IRQ3_Handler
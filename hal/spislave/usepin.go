@@ -0,0 +1,27 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spislave
+
+import "github.com/embeddedgo/kendryte/hal/fpioa"
+
+type Signal uint8
+
+const (
+	D0 Signal = iota // bidirectional data line
+	SS
+	SCLK
+)
+
+// UsePin is a helper function that can be used to configure FPIOA pins as
+// required by SPI slave peripheral.
+func (d *Driver) UsePin(pin fpioa.Pin, sig Signal) {
+	cfg := fpioa.SPI_SLAVE_D0 + fpioa.Config(sig)
+	if sig == D0 {
+		cfg |= fpioa.DriveH34L23 | fpioa.EnOE | fpioa.EnIE | fpioa.Schmitt
+	} else {
+		cfg |= fpioa.EnIE | fpioa.Schmitt
+	}
+	pin.Setup(cfg)
+}
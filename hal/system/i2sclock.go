@@ -0,0 +1,119 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package system
+
+import (
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// I2S clocks
+//
+// The I2S peripheral clock (SCLK in the master mode) and the MCLK are derived
+// from PLL2 using the even dividers:
+//
+//	sclk = pll2 / sdiv, sdiv = 2, 4, ..., 131072
+//	mclk = pll2 / mdiv, mdiv = 2, 4, ..., 512
+//
+// The sample rate is sclk / (2 * sclkPerWord).
+
+// evenDiv returns the even divider in the range [2, max] that gives the
+// frequency closest to f.
+func evenDiv(pll2, f int64, max int) int64 {
+	div := (pll2 + f) / (2 * f) * 2
+	if div < 2 {
+		div = 2
+	} else if div > int64(max) {
+		div = int64(max)
+	}
+	return div
+}
+
+func absErr(f, target int64) int64 {
+	if f > target {
+		return f - target
+	}
+	return target - f
+}
+
+// SetupI2SClock configures PLL2 and the I2Sn clock dividers to obtain the
+// sampleRate for sclkPerWord SCLK cycles per word (16, 24, 32, see i2s.Conf)
+// and the MCLK frequency equal to mclkFs * sampleRate (e.g. 256). Use mclkFs
+// = 0 if MCLK is not used. SetupI2SClock returns the achieved sample rate and
+// its error in ppm (parts per million). The error of MCLK is minimized after
+// the error of the sample rate.
+//
+// PLL2 is common to all I2S peripherals so reconfiguring it changes the
+// clocks of the other I2S peripherals.
+func SetupI2SClock(n, sampleRate, sclkPerWord, mclkFs int) (rate, ppm int) {
+	if uint(n) > 2 {
+		panic("system: bad I2S number")
+	}
+	if sampleRate <= 0 {
+		panic("system: bad I2S sample rate")
+	}
+	switch sclkPerWord {
+	case 16, 24, 32:
+	default:
+		panic("system: bad I2S SCLK cycles per word")
+	}
+	if mclkFs < 0 {
+		panic("system: bad I2S MCLK ratio")
+	}
+	wclk := int64(2 * sclkPerWord * sampleRate)
+	mclk := int64(mclkFs * sampleRate)
+	var (
+		best     pllParams
+		bestSdiv int64
+		bestMdiv int64
+		bestSErr int64 = -1
+		bestMErr int64
+	)
+	pllRange(internal.ClockIn0, func(pp pllParams) {
+		pll2 := pp.freq(internal.ClockIn0)
+		sdiv := evenDiv(pll2, wclk, 131072)
+		e := absErr(pll2/sdiv, wclk) * 1e6 / wclk
+		var mdiv, me int64
+		if mclk != 0 {
+			mdiv = evenDiv(pll2, mclk, 512)
+			me = absErr(pll2/mdiv, mclk) * 1e6 / mclk
+		}
+		if bestSErr < 0 || e < bestSErr || e == bestSErr && me < bestMErr {
+			best, bestSdiv, bestMdiv = pp, sdiv, mdiv
+			bestSErr, bestMErr = e, me
+		}
+	})
+	setPLL(2, best)
+
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+	sth := uint32(bestSdiv/2 - 1)
+	mth := uint32(bestMdiv/2 - 1)
+	mx.CLK_TH.Lock()
+	switch n {
+	case 0:
+		sc.CLK_TH3.StoreBits(sysctl.I2S0_CLK, sysctl.CLK_TH3(sth)<<sysctl.I2S0_CLKn)
+		if mclk != 0 {
+			sc.CLK_TH4.StoreBits(sysctl.I2S0_MCLK, sysctl.CLK_TH4(mth)<<sysctl.I2S0_MCLKn)
+		}
+	case 1:
+		sc.CLK_TH3.StoreBits(sysctl.I2S1_CLK, sysctl.CLK_TH3(sth)<<sysctl.I2S1_CLKn)
+		if mclk != 0 {
+			sc.CLK_TH4.StoreBits(sysctl.I2S1_MCLK, sysctl.CLK_TH4(mth)<<sysctl.I2S1_MCLKn)
+		}
+	case 2:
+		sc.CLK_TH4.StoreBits(sysctl.I2S2_CLK, sysctl.CLK_TH4(sth)<<sysctl.I2S2_CLKn)
+		if mclk != 0 {
+			sc.CLK_TH5.StoreBits(sysctl.I2S2_MCLK, sysctl.CLK_TH5(mth)<<sysctl.I2S2_MCLKn)
+		}
+	}
+	mx.CLK_TH.Unlock()
+
+	pll2 := best.freq(internal.ClockIn0)
+	wdiv := int64(2 * sclkPerWord)
+	rate = int((pll2 + bestSdiv*wdiv/2) / (bestSdiv * wdiv))
+	ppm = int((pll2 - bestSdiv*wclk) * 1e6 / (bestSdiv * wclk))
+	return
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package system
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// The PLL factors and frequencies must be in the following ranges (according
+// to the Kendryte SDK):
+//
+//	1 <= r <= 16, 1 <= f <= 64, 1 <= od <= 16
+//	350 MHz <= vco <= 1750 MHz
+//	fref / r >= 13.67 MHz
+//
+// The last condition means that r = 1 for the 26 MHz IN0 reference clock.
+const (
+	pllVCOMin = 350e6
+	pllVCOMax = 1750e6
+	pllRefMin = 13.67e6
+)
+
+// pllParams describes the PLL configuration.
+type pllParams struct {
+	r, f, od int
+}
+
+// freq returns the PLL output frequency for the fref reference clock.
+func (pp pllParams) freq(fref int64) int64 {
+	return fref * int64(pp.f) / int64(pp.r*pp.od)
+}
+
+// pllRange calls fn for all valid PLL configurations for the fref reference
+// clock.
+func pllRange(fref int64, fn func(pp pllParams)) {
+	for r := 1; r <= 16 && fref/int64(r) >= pllRefMin; r++ {
+		for f := 1; f <= 64; f++ {
+			vco := fref * int64(f) / int64(r)
+			if vco < pllVCOMin {
+				continue
+			}
+			if vco > pllVCOMax {
+				break
+			}
+			for od := 1; od <= 16; od++ {
+				fn(pllParams{r, f, od})
+			}
+		}
+	}
+}
+
+// setPLL configures and enables the n-th PLL. The PLL must not be used as the
+// CPU clock source. The PLL2 uses IN0 as the reference clock.
+func setPLL(n int, pp pllParams) {
+	sc := sysctl.SYSCTL()
+	pll := &sc.PLL[n]
+	mx := &internal.MX.SYSCTL
+	mx.PLL.Lock()
+	pll.ClearBits(sysctl.OUT_EN)
+	pll.ClearBits(sysctl.PWRD) // power down
+	cfg := sysctl.PLL(pp.r-1)<<sysctl.CLKRn |
+		sysctl.PLL(pp.f-1)<<sysctl.CLKFn |
+		sysctl.PLL(pp.od-1)<<sysctl.CLKODn |
+		sysctl.PLL(pp.f-1)<<sysctl.BWADJn
+	mask := sysctl.CLKR | sysctl.CLKF | sysctl.CLKOD | sysctl.BWADJ |
+		sysctl.BYPASS
+	if n == 2 {
+		mask |= sysctl.TEST_EN_CKIN_SEL // select IN0
+	}
+	pll.StoreBits(mask, cfg)
+	pll.SetBits(sysctl.PWRD) // power up
+	time.Sleep(time.Microsecond)
+	pll.SetBits(sysctl.RESET)
+	time.Sleep(time.Microsecond)
+	pll.ClearBits(sysctl.RESET)
+	shift := uint(n * 8)
+	for sc.PLL_LOCK.LoadBits(sysctl.PLL_LOCK0<<shift)>>shift&1 == 0 {
+		sc.PLL_LOCK.SetBits(sysctl.PLL_SLIP_CLEAR0 << shift)
+		runtime.Gosched()
+	}
+	pll.SetBits(sysctl.OUT_EN)
+	mx.PLL.Unlock()
+}
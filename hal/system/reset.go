@@ -0,0 +1,75 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package system
+
+import (
+	"sync"
+
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// ResetReason describes the source of the last system reset.
+type ResetReason uint8
+
+const (
+	PowerOn   ResetReason = iota // power-on reset
+	PinReset                     // external reset pin
+	WDT0Reset                    // watchdog timer 0
+	WDT1Reset                    // watchdog timer 1
+	SoftReset                    // software reset (see Reboot)
+)
+
+var resetReasonStr = [...]string{
+	PowerOn:   "power-on",
+	PinReset:  "pin",
+	WDT0Reset: "WDT0",
+	WDT1Reset: "WDT1",
+	SoftReset: "soft",
+}
+
+func (r ResetReason) String() string {
+	if int(r) < len(resetReasonStr) {
+		return resetReasonStr[r]
+	}
+	return "unknown"
+}
+
+var resetReason struct {
+	once sync.Once
+	r    ResetReason
+}
+
+// LastReset returns the reason of the last system reset. The first call reads
+// the sysctl RESET_STATUS register and clears it so the next reset is reported
+// correctly. The subsequent calls return the same value.
+func LastReset() ResetReason {
+	rr := &resetReason
+	rr.once.Do(func() {
+		rs := &sysctl.SYSCTL().RESET_STATUS
+		st := rs.Load()
+		switch {
+		case st&sysctl.SOFT_RESET_STS != 0:
+			rr.r = SoftReset
+		case st&sysctl.WDT0_RESET_STS != 0:
+			rr.r = WDT0Reset
+		case st&sysctl.WDT1_RESET_STS != 0:
+			rr.r = WDT1Reset
+		case st&sysctl.PIN_RESET_STS != 0:
+			rr.r = PinReset
+		default:
+			rr.r = PowerOn
+		}
+		rs.SetBits(sysctl.RESET_STS_CLR)
+		rs.ClearBits(sysctl.RESET_STS_CLR)
+	})
+	return rr.r
+}
+
+// Reboot performs the software reset of the whole system. It never returns.
+func Reboot() {
+	sysctl.SYSCTL().SOFT_RST().Set()
+	for {
+	}
+}
@@ -2,7 +2,8 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-package setup
+// Package system provides the system level configuration (clocks, PLLs).
+package system
 
 // According to the information in the U-Boot source code the K210 PLL seems to
 // be True Circuits, Inc. General-Purpose PLL.
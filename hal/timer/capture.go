@@ -0,0 +1,178 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timer
+
+import (
+	"embedded/rtos"
+	"sync/atomic"
+	"time"
+
+	"github.com/embeddedgo/kendryte/hal/gpiohs"
+	"github.com/embeddedgo/kendryte/hal/irq"
+)
+
+// Pulse describes one measured pulse. All values are in timer clock ticks.
+type Pulse struct {
+	Start  uint64 // timestamp of the rising edge
+	Width  uint64 // high time (from the rising to the falling edge)
+	Period uint64 // time from the previous rising edge (0 if unknown)
+}
+
+// Capture measures the pulse widths and periods of the digital signal
+// connected to a GPIOHS pin. It timestamps the GPIOHS edge interrupts using a
+// free-running timer channel extended to 64 bits by counting its overflows.
+//
+// The GPIOHS interrupt handler must be provided by user and call ISR, e.g.:
+//
+//	//go:interrupthandler
+//	func _GPIOHS5_Handler() { capture.ISR() }
+//
+//	//go:linkname _GPIOHS5_Handler IRQ39_Handler
+type Capture struct {
+	d    *Driver
+	n    int
+	port *gpiohs.Port
+	pin  gpiohs.Pins
+	c    chan Pulse
+	ovf  uint32
+
+	rise    uint64 // timestamp of the last rising edge
+	hasRise bool
+	period  uint64
+}
+
+// NewCapture returns a new capture that uses the n-th timer channel as the
+// time base and measures the signal on GPIOHS pin (0 to 31). The measured
+// pulses are sent to the channel with bufLen elements (see C).
+func (d *Driver) NewCapture(n, pin, bufLen int) *Capture {
+	return &Capture{
+		d:    d,
+		n:    n,
+		port: gpiohs.P(0),
+		pin:  gpiohs.Pin0 << uint(pin),
+		c:    make(chan Pulse, bufLen),
+	}
+}
+
+// C returns the channel that receives the measured pulses. The ISR never
+// blocks on it so the pulses are dropped if the receiver is too slow.
+func (cp *Capture) C() <-chan Pulse {
+	return cp.c
+}
+
+// Start starts the time base and enables the edge interrupts.
+func (cp *Capture) Start() {
+	d := cp.d
+	d.Stop(cp.n)
+	atomic.StoreUint32(&cp.ovf, 0)
+	cp.hasRise = false
+	d.h[cp.n].Store(&handler{f: cp.overflow})
+	c := d.p.Channel(cp.n)
+	c.load_count.Store(0xFFFFFFFF)
+	c.EnableIRQ()
+
+	p := cp.port
+	p.OutEn.Clear(cp.pin)
+	p.InpEn.Set(cp.pin)
+	p.RiseIP.Store(cp.pin)
+	p.FallIP.Store(cp.pin)
+	p.RiseIE.Set(cp.pin)
+	p.FallIE.Set(cp.pin)
+	ir, ctx := cp.irq()
+	ir.Enable(rtos.IntPrioLow, ctx)
+}
+
+// Stop disables the edge interrupts and stops the time base.
+func (cp *Capture) Stop() {
+	p := cp.port
+	p.RiseIE.Clear(cp.pin)
+	p.FallIE.Clear(cp.pin)
+	ir, ctx := cp.irq()
+	ir.Disable(ctx)
+	cp.d.Stop(cp.n)
+}
+
+// irq returns the GPIOHS interrupt of the pin and the context it is handled
+// in.
+func (cp *Capture) irq() (rtos.IRQ, rtos.IntCtx) {
+	n := 0
+	for cp.pin>>uint(n) != 1 {
+		n++
+	}
+	ir := irq.GPIOHS0 + rtos.IRQ(n)
+	if ir&1 != 0 {
+		return ir, irq.M1
+	}
+	return ir, irq.M0
+}
+
+func (cp *Capture) overflow() {
+	atomic.AddUint32(&cp.ovf, 1)
+}
+
+// Now returns the current value of the time base in ticks.
+func (cp *Capture) Now() uint64 {
+	c := cp.d.p.Channel(cp.n)
+	for {
+		o := atomic.LoadUint32(&cp.ovf)
+		ovf := o
+		el := uint64(0xFFFFFFFF - c.current.Load())
+		if c.intstat.Load()&1 != 0 && el < 1<<31 {
+			ovf++ // counter reloaded but overflow not handled yet
+		}
+		if atomic.LoadUint32(&cp.ovf) == o {
+			return uint64(ovf)<<32 + el
+		}
+	}
+}
+
+// Duration converts ticks to time.Duration.
+func (cp *Capture) Duration(ticks uint64) time.Duration {
+	clk := uint64(cp.d.p.Clock())
+	s := ticks / clk
+	return time.Duration(s)*time.Second +
+		time.Duration((ticks-s*clk)*1e9/clk)
+}
+
+// ISR handles the GPIOHS interrupt of the pin.
+func (cp *Capture) ISR() {
+	t := cp.Now()
+	p := cp.port
+	rise := p.RiseIP.Load()&cp.pin != 0
+	fall := p.FallIP.Load()&cp.pin != 0
+	p.RiseIP.Store(cp.pin)
+	p.FallIP.Store(cp.pin)
+	if rise && fall && p.InpVal.Load()&cp.pin == 0 {
+		// both edges pending and pin is low: rising edge was the first one
+		cp.edge(true, t)
+		cp.edge(false, t)
+		return
+	}
+	if fall {
+		cp.edge(false, t)
+	}
+	if rise {
+		cp.edge(true, t)
+	}
+}
+
+func (cp *Capture) edge(rise bool, t uint64) {
+	if rise {
+		if cp.hasRise {
+			cp.period = t - cp.rise
+		} else {
+			cp.period = 0
+		}
+		cp.rise, cp.hasRise = t, true
+		return
+	}
+	if !cp.hasRise {
+		return
+	}
+	select {
+	case cp.c <- Pulse{Start: cp.rise, Width: t - cp.rise, Period: cp.period}:
+	default:
+	}
+}
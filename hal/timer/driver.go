@@ -0,0 +1,140 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timer
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Driver is an interrupt driven timer service that uses the four channels of
+// the TIMER peripheral as independent one-shot or periodic timers. It handles
+// both interrupts of the peripheral (TIMERnA for channels 0, 1 and TIMERnB
+// for channels 2, 3).
+//
+// The callbacks passed to AfterFunc and TickFunc are called by the interrupt
+// handler so they must be short and must not block. The channels returned by
+// After and Tick have one element buffer. The ISR never blocks on them so the
+// ticks are dropped if the receiver is too slow (as in case of time.Ticker).
+//
+// The callbacks may call Stop, AfterFunc, TickFunc, After and Tick, also for
+// their own timer (e.g. to re-arm it with a different period).
+type Driver struct {
+	p *Periph
+	h [4]atomic.Pointer[handler]
+}
+
+type handler struct {
+	f       func()
+	c       chan struct{}
+	oneShot bool
+}
+
+// NewDriver returns a new driver for p.
+func NewDriver(p *Periph) *Driver {
+	return &Driver{p: p}
+}
+
+func (d *Driver) Periph() *Periph {
+	return d.p
+}
+
+// Ticks returns the number of timer clock cycles corresponding to dur (see
+// Periph.Clock). Ticks panics if the result does not
+// fit in the 31-bit range of the channel counter.
+func (d *Driver) Ticks(dur time.Duration) int {
+	clk := d.p.Clock()
+	s := int64(dur / time.Second)
+	ns := int64(dur % time.Second)
+	t := s*clk + (ns*clk+5e8)/1e9
+	if t < 1 || t > 0x7FFFFFFF || s > 0x7FFFFFFF {
+		panic("timer: duration out of range")
+	}
+	return int(t)
+}
+
+func (d *Driver) start(n int, dur time.Duration, h handler) {
+	ticks := d.Ticks(dur)
+	d.Stop(n)
+	d.h[n].Store(&h)
+	c := d.p.Channel(n)
+	c.SetLowTicks(ticks)
+	c.EnableIRQ()
+}
+
+// Stop stops the n-th timer. It does not wait for the callback of the n-th
+// timer that can be running at the same time on the other hart but the
+// callback is not called again.
+func (d *Driver) Stop(n int) {
+	c := d.p.Channel(n)
+	c.control.ClearBits(enable)
+	c.DisableIRQ()
+	c.ClearIRQ()
+	d.h[n].Store(nil)
+}
+
+// AfterFunc uses the n-th timer to call f after the dur duration.
+func (d *Driver) AfterFunc(n int, dur time.Duration, f func()) {
+	d.start(n, dur, handler{f: f, oneShot: true})
+}
+
+// TickFunc uses the n-th timer to call f periodically, every period.
+func (d *Driver) TickFunc(n int, period time.Duration, f func()) {
+	d.start(n, period, handler{f: f})
+}
+
+// After uses the n-th timer to send a value to the returned channel after the
+// dur duration.
+func (d *Driver) After(n int, dur time.Duration) <-chan struct{} {
+	c := make(chan struct{}, 1)
+	d.start(n, dur, handler{c: c, oneShot: true})
+	return c
+}
+
+// Tick uses the n-th timer to send values to the returned channel
+// periodically, every period.
+func (d *Driver) Tick(n int, period time.Duration) <-chan struct{} {
+	c := make(chan struct{}, 1)
+	d.start(n, period, handler{c: c})
+	return c
+}
+
+// ISRA handles the TIMERnA interrupt (channels 0 and 1).
+func (d *Driver) ISRA() {
+	d.handle(0, 1)
+}
+
+// ISRB handles the TIMERnB interrupt (channels 2 and 3).
+func (d *Driver) ISRB() {
+	d.handle(2, 3)
+}
+
+func (d *Driver) handle(first, last int) {
+	st := d.p.intstat.Load()
+	for n := first; n <= last; n++ {
+		if st&(1<<uint(n)) == 0 {
+			continue
+		}
+		c := d.p.Channel(n)
+		c.ClearIRQ()
+		h := d.h[n].Load()
+		if h == nil {
+			continue
+		}
+		if h.oneShot {
+			c.control.ClearBits(enable)
+			c.DisableIRQ()
+		}
+		if h.f != nil {
+			h.f()
+		}
+		if h.c != nil {
+			select {
+			case h.c <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
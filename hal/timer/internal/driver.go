@@ -0,0 +1,23 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"embedded/rtos"
+
+	"github.com/embeddedgo/kendryte/hal/irq"
+	"github.com/embeddedgo/kendryte/hal/timer"
+)
+
+// TIMER returns a ready to use driver for TIMERn peripheral.
+func TIMER(n int) *timer.Driver {
+	p := timer.TIMER(n)
+	p.EnableClock()
+	driver := timer.NewDriver(p) // must before ir.Enable
+	ir := irq.TIMER0A + rtos.IRQ(n*2)
+	ir.Enable(rtos.IntPrioLow, irq.M0)       // TIMERnA, even
+	(ir + 1).Enable(rtos.IntPrioLow, irq.M1) // TIMERnB, odd
+	return driver
+}
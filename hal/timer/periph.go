@@ -43,6 +43,12 @@ func (p *Periph) Bus() bus.Bus {
 	return bus.APB0
 }
 
+// Clock returns the frequency of the timer counters in Hz (twice the bus
+// clock). All tick counts used by this package are in this clock.
+func (p *Periph) Clock() int64 {
+	return p.Bus().Clock() * 2
+}
+
 func (p *Periph) n() uintptr {
 	return (uintptr(unsafe.Pointer(p)) - mmap.TIMER0_BASE) / 0x10000
 }
@@ -112,7 +118,7 @@ func (c *Channel) Periph() *Periph {
 // count down. i.e. the interval when operating as a timer, or the high period
 // when operating as a PWM.
 // Conversion from time units to ticks can be calculated by retrieaving the
-// peripherals bus clock with: myChannel.Periph().Bus().Clock()
+// timer clock with: myChannel.Periph().Clock()
 func (c *Channel) SetLowTicks(ticks int) {
 	if ticks < 0 || ticks > 2147483647 {
 		panic("timer: period outside of 32bit range")
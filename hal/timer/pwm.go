@@ -18,10 +18,16 @@ func (d *PWM) Enable() {
 	d.control.SetBits(pwmEnable | enable | userMode | interruptMask)
 }
 
+// Clock returns the frequency of the ticks used by SetFrequency, SetLowTicks
+// and SetHighTicks in Hz.
+func (d *PWM) Clock() int64 {
+	return d.Periph().Clock()
+}
+
 // SetFrequency assigns the PWM channel with a clock rate in Hz and duty cycle
 // between 0.0 and 1.0
 func (d *PWM) SetFrequency(frequency float64, duty float64) {
-	clk := float64(d.Periph().Bus().Clock() * 2)
+	clk := float64(d.Clock())
 
 	if frequency < 0 || frequency > 2147483647 {
 		panic("pwm: frequency outside of 32bit range")
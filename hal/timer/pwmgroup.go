@@ -0,0 +1,166 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timer
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// PWMGroup drives a group of PWM channels of one timer with the common period.
+// Every period begins with the low phase (load_count) followed by the high
+// phase (load_count2). The duty cycle of a channel is the length of its high
+// phase in ticks (see PWM.SetFrequency, SetHighTicks).
+//
+// All channels are started together. The new duty cycles (and the period) are
+// applied by the interrupt handler of the first (reference) channel in the
+// group at the period boundary, that is at the falling edge that ends the
+// high phase of the reference channel. The handler restarts all channels
+// together with the new settings so every channel keeps the common period
+// boundary. The period that ends at the update is not shortened but the
+// next one starts with the interrupt latency delay (the outputs stay low
+// during it).
+//
+// The reference channel interrupt occurs at both edges and the handler tells
+// them apart by counting, so both phases of the reference channel should be
+// longer than the interrupt latency. A missed interrupt can cause one update
+// to occur at the rising edge (the update realigns the counting).
+type PWMGroup struct {
+	d      *Driver
+	chs    []int
+	period uint32    // period in ticks
+	duty   [4]uint32 // duty cycles in ticks
+	pend   uint32    // bit n set means the channel n has to be updated
+	high   bool      // the reference channel is in its high phase (ISR only)
+}
+
+// NewPWMGroup returns a new PWM group that consists of the chs channels of
+// the timer. The first channel in chs is the reference one and its interrupt
+// is used to update the group. The group period is initially set to 1 ms and
+// all duty cycles to 0.
+func (d *Driver) NewPWMGroup(chs ...int) *PWMGroup {
+	if len(chs) == 0 {
+		panic("timer: empty PWM group")
+	}
+	g := &PWMGroup{d: d, chs: chs}
+	g.period = uint32(d.Ticks(time.Millisecond))
+	return g
+}
+
+// SetPeriodTicks sets the period of the group in ticks (at least 2). The
+// duty cycles expressed in ticks are not changed.
+func (g *PWMGroup) SetPeriodTicks(ticks int) {
+	if ticks < 2 || ticks > 0x7FFFFFFF {
+		panic("timer: PWM period out of range")
+	}
+	atomic.StoreUint32(&g.period, uint32(ticks))
+	for _, n := range g.chs {
+		atomic.OrUint32(&g.pend, 1<<uint(n))
+	}
+}
+
+// SetPeriod sets the period of the group and returns it in ticks.
+func (g *PWMGroup) SetPeriod(period time.Duration) int {
+	ticks := g.d.Ticks(period)
+	g.SetPeriodTicks(ticks)
+	return ticks
+}
+
+// PeriodTicks returns the period of the group in ticks.
+func (g *PWMGroup) PeriodTicks() int {
+	return int(atomic.LoadUint32(&g.period))
+}
+
+// SetDutyTicks sets the duty cycle of the n-th channel in ticks. The 0 and
+// period values are approximated by 1 and period-1 because the timer does
+// not support the zero length phases.
+func (g *PWMGroup) SetDutyTicks(n, ticks int) {
+	if ticks < 0 {
+		ticks = 0
+	}
+	atomic.StoreUint32(&g.duty[n], uint32(ticks))
+	atomic.OrUint32(&g.pend, 1<<uint(n))
+}
+
+// SetDutyPermille sets the duty cycle of the n-th channel in permille of the
+// current period (0 to 1000).
+func (g *PWMGroup) SetDutyPermille(n, pm int) {
+	if pm < 0 {
+		pm = 0
+	} else if pm > 1000 {
+		pm = 1000
+	}
+	period := int64(atomic.LoadUint32(&g.period))
+	g.SetDutyTicks(n, int((period*int64(pm)+500)/1000))
+}
+
+// counts returns the load_count and load_count2 values for the n-th channel.
+func (g *PWMGroup) counts(n int) (low, high uint32) {
+	period := atomic.LoadUint32(&g.period)
+	high = atomic.LoadUint32(&g.duty[n])
+	if high < 1 {
+		high = 1
+	} else if high > period-1 {
+		high = period - 1
+	}
+	return period - high, high
+}
+
+// Start programs and starts all channels of the group. The channels are
+// enabled one after the other in a tight loop so their relative phase offset
+// is a few bus clock cycles.
+func (g *PWMGroup) Start() {
+	d := g.d
+	p := d.p
+	for i, n := range g.chs {
+		d.Stop(n)
+		cfg := pwmEnable | userMode
+		if i != 0 {
+			cfg |= interruptMask
+		}
+		p.ch[n].control.Store(cfg)
+	}
+	d.h[g.chs[0]].Store(&handler{f: g.update})
+	g.restart()
+}
+
+// restart loads the current settings to all channels of the group and
+// starts them together, beginning with the low phase.
+func (g *PWMGroup) restart() {
+	p := g.d.p
+	atomic.StoreUint32(&g.pend, 0)
+	for _, n := range g.chs {
+		p.ch[n].control.ClearBits(enable)
+	}
+	for _, n := range g.chs {
+		lo, hi := g.counts(n)
+		p.ch[n].load_count.Store(lo)
+		p.load_count2[n].Store(hi)
+	}
+	p.ch[g.chs[0]].ClearIRQ()
+	g.high = false
+	for _, n := range g.chs {
+		p.ch[n].control.SetBits(enable)
+	}
+}
+
+// Stop stops all channels of the group.
+func (g *PWMGroup) Stop() {
+	for _, n := range g.chs {
+		g.d.Stop(n)
+	}
+}
+
+// update is called by the interrupt handler at both edges of the reference
+// channel. It restarts the group at the falling edge (the period boundary) if
+// there are pending changes.
+func (g *PWMGroup) update() {
+	if g.high = !g.high; g.high {
+		return // rising edge, the high phase has just begun
+	}
+	if atomic.LoadUint32(&g.pend) != 0 {
+		g.restart()
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package servo provides helpers to control the hobby servos and ESCs (motor
+// speed controllers) using the timer PWM channels.
+package servo
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/embeddedgo/kendryte/hal/timer"
+)
+
+// Default calibration.
+const (
+	DefaultPeriod = 20000 // µs (50 Hz)
+	DefaultMin    = 1000  // µs
+	DefaultMax    = 2000  // µs
+	DefaultRange  = 180   // degrees
+)
+
+// Servo controls a servo or an ESC connected to the PWM channel. The pulse
+// width is generated as the high ticks of the PWM (see timer.PWM.SetHighTicks).
+type Servo struct {
+	mx     sync.Mutex // protects the fields below and serializes set
+	pwm    *timer.PWM
+	period int // µs
+	min    int // µs
+	max    int // µs
+	rng    int // degrees
+	pulse  int32
+	ramp   uint32 // ramp generation, incremented to cancel the running ramp
+	moving int32
+}
+
+// New returns a new servo that uses pwm with the default calibration. It
+// enables pwm and sets the pulse width to the middle of the calibrated range.
+func New(pwm *timer.PWM) *Servo {
+	s := &Servo{
+		pwm:    pwm,
+		period: DefaultPeriod,
+		min:    DefaultMin,
+		max:    DefaultMax,
+		rng:    DefaultRange,
+	}
+	s.SetPulse((s.min + s.max) / 2)
+	pwm.Enable()
+	return s
+}
+
+// SetCalibration sets the PWM period, the pulse widths that correspond to the
+// minimum and maximum position and the angle range between them (degrees).
+// All times are in microseconds.
+func (s *Servo) SetCalibration(period, min, max, rng int) {
+	if min <= 0 || max <= min || max >= period || rng <= 0 {
+		panic("servo: bad calibration")
+	}
+	s.mx.Lock()
+	s.period, s.min, s.max, s.rng = period, min, max, rng
+	s.ramp++
+	s.set(s.Pulse())
+	s.mx.Unlock()
+}
+
+// ticks converts µs to the PWM ticks.
+func (s *Servo) ticks(us int) int {
+	return int((s.pwm.Clock()*int64(us) + 5e5) / 1e6)
+}
+
+func (s *Servo) clamp(us int) int {
+	if us < s.min {
+		return s.min
+	}
+	if us > s.max {
+		return s.max
+	}
+	return us
+}
+
+// set sets the pulse width. It must be called with s.mx locked.
+func (s *Servo) set(us int) {
+	us = s.clamp(us)
+	atomic.StoreInt32(&s.pulse, int32(us))
+	hi := s.ticks(us)
+	s.pwm.SetLowTicks(s.ticks(s.period) - hi)
+	s.pwm.SetHighTicks(hi)
+}
+
+// Pulse returns the current pulse width in microseconds.
+func (s *Servo) Pulse() int {
+	return int(atomic.LoadInt32(&s.pulse))
+}
+
+// SetPulse sets the pulse width in microseconds. The width is clamped to the
+// calibrated range. SetPulse cancels the running ramp.
+func (s *Servo) SetPulse(us int) {
+	s.mx.Lock()
+	s.ramp++
+	s.set(us)
+	s.mx.Unlock()
+}
+
+// pulseOf converts the angle to the pulse width.
+func (s *Servo) pulseOf(deg int) int {
+	return s.min + (s.max-s.min)*deg/s.rng
+}
+
+// Angle returns the current angle in degrees.
+func (s *Servo) Angle() int {
+	return (s.Pulse() - s.min) * s.rng / (s.max - s.min)
+}
+
+// SetAngle sets the angle in degrees (0 to the calibrated range).
+func (s *Servo) SetAngle(deg int) {
+	s.SetPulse(s.pulseOf(deg))
+}
+
+// SetThrottle sets the ESC throttle in permille (0 to 1000) of the
+// calibrated range.
+func (s *Servo) SetThrottle(pm int) {
+	s.SetPulse(s.min + (s.max-s.min)*pm/1000)
+}
+
+// setRamp sets the pulse width if the ramp generation is still gen. It
+// reports whether the ramp has not been canceled.
+func (s *Servo) setRamp(us int, gen uint32) bool {
+	s.mx.Lock()
+	ok := s.ramp == gen
+	if ok {
+		s.set(us)
+	}
+	s.mx.Unlock()
+	return ok
+}
+
+// RampPulse moves the servo smoothly from the current position to the pulse
+// width us in the time t. The movement is driven by a goroutine that updates
+// the pulse width once per PWM period. RampPulse returns immediately. Any
+// other Set* or Ramp* call cancels the running ramp.
+func (s *Servo) RampPulse(us int, t time.Duration) {
+	s.mx.Lock()
+	s.ramp++
+	gen := s.ramp
+	from, to := s.Pulse(), s.clamp(us)
+	step := time.Duration(s.period) * time.Microsecond
+	s.mx.Unlock()
+	atomic.AddInt32(&s.moving, 1)
+	go func() {
+		defer atomic.AddInt32(&s.moving, -1)
+		start := time.Now()
+		for {
+			el := time.Since(start)
+			if el >= t {
+				s.setRamp(to, gen)
+				return
+			}
+			if !s.setRamp(from+int(int64(to-from)*int64(el)/int64(t)), gen) {
+				return
+			}
+			time.Sleep(step)
+		}
+	}()
+}
+
+// RampAngle works like RampPulse but takes the target angle in degrees.
+func (s *Servo) RampAngle(deg int, t time.Duration) {
+	s.RampPulse(s.pulseOf(deg), t)
+}
+
+// Moving reports whether a ramp is running.
+func (s *Servo) Moving() bool {
+	return atomic.LoadInt32(&s.moving) != 0
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timer0
+
+import (
+	_ "unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/timer"
+	"github.com/embeddedgo/kendryte/hal/timer/internal"
+)
+
+var driver *timer.Driver
+
+// Driver returns a ready to use driver for TIMER0 peripheral.
+func Driver() *timer.Driver {
+	if driver == nil {
+		driver = internal.TIMER(0)
+	}
+	return driver
+}
+
+//go:interrupthandler
+func _TIMER0A_Handler() { driver.ISRA() }
+
+//go:linkname _TIMER0A_Handler IRQ14_Handler
+
+//go:interrupthandler
+func _TIMER0B_Handler() { driver.ISRB() }
+
+//go:linkname _TIMER0B_Handler IRQ15_Handler
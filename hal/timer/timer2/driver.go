@@ -0,0 +1,32 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timer2
+
+import (
+	_ "unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/timer"
+	"github.com/embeddedgo/kendryte/hal/timer/internal"
+)
+
+var driver *timer.Driver
+
+// Driver returns a ready to use driver for TIMER2 peripheral.
+func Driver() *timer.Driver {
+	if driver == nil {
+		driver = internal.TIMER(2)
+	}
+	return driver
+}
+
+//go:interrupthandler
+func _TIMER2A_Handler() { driver.ISRA() }
+
+//go:linkname _TIMER2A_Handler IRQ18_Handler
+
+//go:interrupthandler
+func _TIMER2B_Handler() { driver.ISRB() }
+
+//go:linkname _TIMER2B_Handler IRQ19_Handler
@@ -0,0 +1,172 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wdt
+
+import (
+	"embedded/rtos"
+	"sync/atomic"
+	"time"
+
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/hal/irq"
+)
+
+// Driver is a watchdog driver.
+//
+// In the interrupt-then-reset mode the first timeout generates an interrupt
+// and the ISR calls the pre-reset callback. The interrupt is not cleared so
+// the system is reset at the end of the next timeout period unless the
+// watchdog is fed in the meantime. The callback is called by the interrupt
+// handler so it must be short and must not block (e.g. it can save some
+// diagnostic information in memory that survives the reset).
+type Driver struct {
+	p      *Periph
+	onIRQ  func()
+	fired  int32
+	period time.Duration
+	stop   chan struct{}
+}
+
+// NewDriver returns a new driver for p.
+func NewDriver(p *Periph) *Driver {
+	return &Driver{p: p}
+}
+
+func (d *Driver) Periph() *Periph {
+	return d.p
+}
+
+// Setup configures and starts the watchdog. It selects the clock divider and
+// the timeout period so the resulting timeout is the shortest one not less
+// than timeout (the available range is from about 5 ms to about 11.7 hours,
+// longer timeouts are clamped to the maximum one).
+// If preReset is not nil the watchdog works in the interrupt-then-reset mode
+// and the system reset occurs after two timeout periods. Setup returns the
+// length of one timeout period.
+func (d *Driver) Setup(timeout time.Duration, preReset func()) time.Duration {
+	d.Stop()
+	// Clamp the timeout before converting it to clock cycles so the
+	// calculations below can not overflow.
+	const maxTimeout = time.Duration(512<<(16+MaxTop)/int64(internal.ClockIn0/1e6)) * 1e3
+	if timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+	bestDiv, bestTop := 0, 0
+	best := int64(-1)
+	for top := 0; top <= MaxTop; top++ {
+		n := int64(1) << (16 + top)
+		// div = ceil(timeout * clkIn0 / n) rounded up to the even number
+		div := (int64(timeout)*(internal.ClockIn0/1e6)/1e3 + n - 1) / n
+		div += div & 1
+		if div < 2 {
+			div = 2
+		} else if div > 512 {
+			continue
+		}
+		if t := n * div; best < 0 || t < best {
+			best, bestDiv, bestTop = t, int(div), top
+		}
+	}
+	if best < 0 {
+		bestDiv, bestTop = 512, MaxTop // timeout too long
+	}
+	p := d.p
+	p.SetClockDiv(bestDiv)
+	p.SetTop(bestTop)
+	d.onIRQ = preReset
+	atomic.StoreInt32(&d.fired, 0)
+	mode := ResetOnly
+	if preReset != nil {
+		mode = IRQReset
+	}
+	p.Enable(mode)
+	p.Restart()
+	if preReset != nil {
+		ir, ctx := d.irq()
+		ir.Enable(rtos.IntPrioHigh, ctx)
+	}
+	const clk = internal.ClockIn0
+	n := int64(p.ClockDiv()) << (16 + p.Top())
+	d.period = time.Duration(n/clk*1e9 + n%clk*1e9/clk)
+	return d.period
+}
+
+// Period returns the length of the timeout period set by Setup.
+func (d *Driver) Period() time.Duration {
+	return d.period
+}
+
+// Feed restarts the watchdog counter. It also cancels the pending system
+// reset if the pre-reset interrupt has already occurred.
+func (d *Driver) Feed() {
+	d.p.Restart()
+	if atomic.CompareAndSwapInt32(&d.fired, 1, 0) {
+		ir, ctx := d.irq()
+		ir.Enable(rtos.IntPrioHigh, ctx)
+	}
+}
+
+// Supervise starts a goroutine that feeds the watchdog every interval as
+// long as alive returns true. If alive returns false the supervisor stops
+// feeding and the watchdog resets the system at the end of the timeout. The
+// nil alive is treated as always returning true. The interval should be
+// significantly shorter than the timeout period returned by Setup.
+func (d *Driver) Supervise(interval time.Duration, alive func() bool) {
+	d.stopSupervisor()
+	stop := make(chan struct{})
+	d.stop = stop
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				if alive != nil && !alive() {
+					return
+				}
+				d.Feed()
+			}
+		}
+	}()
+}
+
+func (d *Driver) stopSupervisor() {
+	if d.stop != nil {
+		close(d.stop)
+		d.stop = nil
+	}
+}
+
+// Stop stops the supervisor goroutine (if any) and disables the watchdog.
+func (d *Driver) Stop() {
+	d.stopSupervisor()
+	ir, ctx := d.irq()
+	ir.Disable(ctx)
+	d.p.Disable()
+	d.p.Restart()
+	atomic.StoreInt32(&d.fired, 0)
+}
+
+// irq returns the WDT interrupt and the context it is handled in.
+func (d *Driver) irq() (rtos.IRQ, rtos.IntCtx) {
+	ir := irq.WDT0 + rtos.IRQ(d.p.n())
+	if ir&1 != 0 {
+		return ir, irq.M1
+	}
+	return ir, irq.M0
+}
+
+// ISR is the interrupt handler. It disables the watchdog interrupt in the
+// interrupt controller (the peripheral interrupt stays pending to allow the
+// system reset) and calls the pre-reset callback.
+func (d *Driver) ISR() {
+	ir, ctx := d.irq()
+	ir.Disable(ctx)
+	if atomic.CompareAndSwapInt32(&d.fired, 0, 1) && d.onIRQ != nil {
+		d.onIRQ()
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import "github.com/embeddedgo/kendryte/hal/wdt"
+
+// WDT returns a ready to use driver for WDTn peripheral. The interrupt is
+// enabled by Driver.Setup if the pre-reset callback is used.
+func WDT(n int) *wdt.Driver {
+	p := wdt.WDT(n)
+	p.EnableClock()
+	return wdt.NewDriver(p)
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package wdt provides interface to the watchdog timers.
+package wdt
+
+import (
+	"embedded/mmio"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/internal"
+	"github.com/embeddedgo/kendryte/hal/system"
+	"github.com/embeddedgo/kendryte/p/bus"
+	"github.com/embeddedgo/kendryte/p/mmap"
+	"github.com/embeddedgo/kendryte/p/sysctl"
+)
+
+// Synopsys DW_apb_wdt
+
+// Periph represents the watchdog timer peripheral.
+type Periph struct {
+	cr   mmio.U32
+	torr mmio.U32
+	ccvr mmio.U32
+	crr  mmio.U32
+	stat mmio.U32
+	eoi  mmio.U32
+	_    uint32
+	prot mmio.U32
+}
+
+// WDT returns n-th watchdog timer (n = 0, 1).
+func WDT(n int) *Periph {
+	if uint(n) > 1 {
+		panic("wdt: bad number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.WDT0_BASE + uintptr(n)*0x10000))
+}
+
+func (p *Periph) Bus() bus.Bus {
+	return bus.APB1
+}
+
+// n returns WDT number.
+func (p *Periph) n() uint {
+	return uint((uintptr(unsafe.Pointer(p)) - mmap.WDT0_BASE) / 0x10000)
+}
+
+func (p *Periph) EnableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_CENT.Lock()
+	if mx.APB1_CLK_EN == 0 {
+		sc.APB1_CLK_EN().Set()
+	}
+	mx.APB1_CLK_EN++
+	mx.CLK_EN_CENT.Unlock()
+
+	mx.CLK_EN_PERI.Lock()
+	sc.CLK_EN_PERI.SetBits(sysctl.WDT0_CLK_EN << p.n())
+	mx.CLK_EN_PERI.Unlock()
+}
+
+func (p *Periph) DisableClock() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.CLK_EN_PERI.Lock()
+	sc.CLK_EN_PERI.ClearBits(sysctl.WDT0_CLK_EN << p.n())
+	mx.CLK_EN_PERI.Unlock()
+
+	mx.CLK_EN_CENT.Lock()
+	mx.APB1_CLK_EN--
+	if mx.APB1_CLK_EN == 0 {
+		sc.APB1_CLK_EN().Clear()
+	}
+	mx.CLK_EN_CENT.Unlock()
+}
+
+func (p *Periph) Reset() {
+	sc := sysctl.SYSCTL()
+	mx := &internal.MX.SYSCTL
+
+	mx.PERI_RESET.Lock()
+	sc.PERI_RESET.SetBits(sysctl.WDT0_RESET << p.n())
+	mx.PERI_RESET.Unlock()
+
+	time.Sleep(10 * time.Microsecond)
+
+	mx.PERI_RESET.Lock()
+	sc.PERI_RESET.ClearBits(sysctl.WDT0_RESET << p.n())
+	mx.PERI_RESET.Unlock()
+}
+
+// ClockDiv returns the current divider of the IN0 clock (even number from 2
+// to 512).
+func (p *Periph) ClockDiv() int {
+	sh := p.n() * 8
+	th := sysctl.SYSCTL().CLK_TH6.LoadBits(sysctl.WDT0_CLK << sh)
+	return (int(th>>(sysctl.WDT0_CLKn+sh)) + 1) * 2
+}
+
+// SetClockDiv sets the divider of the IN0 clock. The div is rounded down to
+// the even number and clamped to the range from 2 to 512.
+func (p *Periph) SetClockDiv(div int) {
+	th := div/2 - 1
+	if th < 0 {
+		th = 0
+	} else if th > 0xFF {
+		th = 0xFF
+	}
+	sh := p.n() * 8
+	mx := &internal.MX.SYSCTL
+	mx.CLK_TH.Lock()
+	sysctl.SYSCTL().CLK_TH6.StoreBits(sysctl.WDT0_CLK<<sh, sysctl.CLK_TH6(th)<<(sysctl.WDT0_CLKn+sh))
+	mx.CLK_TH.Unlock()
+}
+
+// Clock returns the frequency of the watchdog clock in Hz. It is derived from
+// IN0 and divided by the CLK_TH6 threshold (see ClockDiv).
+func (p *Periph) Clock() int64 {
+	return internal.ClockIn0 / int64(p.ClockDiv())
+}
+
+// Mode represents the watchdog response mode.
+type Mode uint8
+
+const (
+	ResetOnly Mode = 0 // system reset on timeout
+	IRQReset  Mode = 1 // interrupt on timeout, system reset on second timeout
+)
+
+// Enable enables the watchdog in the mode m.
+func (p *Periph) Enable(m Mode) {
+	p.cr.Store(uint32(m)<<1 | 1)
+}
+
+// Disable disables the watchdog.
+func (p *Periph) Disable() {
+	p.cr.ClearBits(1)
+}
+
+// Enabled reports whether the watchdog is enabled.
+func (p *Periph) Enabled() bool {
+	return p.cr.Load()&1 != 0
+}
+
+// MaxTop is the maximum value accepted by SetTop.
+const MaxTop = 15
+
+// SetTop sets the timeout period to 1<<(16+top) watchdog clock cycles.
+func (p *Periph) SetTop(top int) {
+	if uint(top) > MaxTop {
+		panic("wdt: bad timeout period")
+	}
+	p.torr.Store(uint32(top)<<4 | uint32(top))
+}
+
+// Top returns the current timeout period setting.
+func (p *Periph) Top() int {
+	return int(p.torr.Load() & 15)
+}
+
+// Restart restarts the counter (feeds the watchdog) and clears the interrupt.
+func (p *Periph) Restart() {
+	p.crr.Store(0x76)
+}
+
+// Counter returns the current value of the counter.
+func (p *Periph) Counter() uint32 {
+	return p.ccvr.Load()
+}
+
+// IRQPending reports whether the interrupt is pending.
+func (p *Periph) IRQPending() bool {
+	return p.stat.Load()&1 != 0
+}
+
+// ClearIRQ clears the interrupt without restarting the counter.
+func (p *Periph) ClearIRQ() {
+	p.eoi.Load()
+}
+
+// CausedReset reports whether the last system reset was caused by this
+// watchdog (see system.LastReset).
+func (p *Periph) CausedReset() bool {
+	return system.LastReset() == system.WDT0Reset+system.ResetReason(p.n())
+}
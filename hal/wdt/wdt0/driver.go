@@ -0,0 +1,27 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wdt0
+
+import (
+	_ "unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/wdt"
+	"github.com/embeddedgo/kendryte/hal/wdt/internal"
+)
+
+var driver *wdt.Driver
+
+// Driver returns a ready to use driver for WDT0 peripheral.
+func Driver() *wdt.Driver {
+	if driver == nil {
+		driver = internal.WDT(0)
+	}
+	return driver
+}
+
+//go:interrupthandler
+func _WDT0_Handler() { driver.ISR() }
+
+//go:linkname _WDT0_Handler IRQ21_Handler
@@ -0,0 +1,27 @@
+// Copyright 2026 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wdt1
+
+import (
+	_ "unsafe"
+
+	"github.com/embeddedgo/kendryte/hal/wdt"
+	"github.com/embeddedgo/kendryte/hal/wdt/internal"
+)
+
+var driver *wdt.Driver
+
+// Driver returns a ready to use driver for WDT1 peripheral.
+func Driver() *wdt.Driver {
+	if driver == nil {
+		driver = internal.WDT(1)
+	}
+	return driver
+}
+
+//go:interrupthandler
+func _WDT1_Handler() { driver.ISR() }
+
+//go:linkname _WDT1_Handler IRQ22_Handler
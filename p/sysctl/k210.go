@@ -212,6 +212,14 @@ const (
 	RTC_CLK_ENn    = 29
 )
 
+const (
+	SOFT_RST SOFT_RESET = 0x01 << 0 //+
+)
+
+const (
+	SOFT_RSTn = 0
+)
+
 const (
 	ROM_RESET    PERI_RESET = 0x01 << 0  //+
 	DMA_RESET    PERI_RESET = 0x01 << 1  //+
@@ -482,6 +482,10 @@ type RMSOFT_RESET struct{ mmio.UM32 }
 func (rm RMSOFT_RESET) Load() SOFT_RESET   { return SOFT_RESET(rm.UM32.Load()) }
 func (rm RMSOFT_RESET) Store(b SOFT_RESET) { rm.UM32.Store(uint32(b)) }
 
+func (p *Periph) SOFT_RST() RMSOFT_RESET {
+	return RMSOFT_RESET{mmio.UM32{&p.SOFT_RESET.U32, uint32(SOFT_RST)}}
+}
+
 type PERI_RESET uint32
 
 type RPERI_RESET struct{ mmio.U32 }